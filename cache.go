@@ -3,16 +3,71 @@ package generic
 import (
 	"errors"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 type Cache[K comparable, V any] struct {
 	innerMap sync.Map
+	loadSem  semaphore
+}
+
+// CacheOption configures a Cache created by NewCache.
+type CacheOption func(c *cacheOptions)
+
+type cacheOptions struct {
+	maxConcurrentLoads int
+}
+
+// WithMaxConcurrentLoads bounds how many distinct keys can be loading at the same time.
+// Loads beyond the limit block until a running load finishes. A value <= 0 means no limit.
+func WithMaxConcurrentLoads(n int) CacheOption {
+	return func(c *cacheOptions) {
+		c.maxConcurrentLoads = n
+	}
+}
+
+// NewCache creates a Cache configured with the given options.
+// The zero value of Cache is also ready to use, without any limits.
+func NewCache[K comparable, V any](opts ...CacheOption) *Cache[K, V] {
+	options := cacheOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return &Cache[K, V]{
+		loadSem: newSemaphore(options.maxConcurrentLoads),
+	}
+}
+
+type semaphore chan struct{}
+
+func newSemaphore(n int) semaphore {
+	if n <= 0 {
+		return nil
+	}
+	return make(semaphore, n)
+}
+
+func (s semaphore) acquire() {
+	if s != nil {
+		s <- struct{}{}
+	}
+}
+
+func (s semaphore) release() {
+	if s != nil {
+		<-s
+	}
 }
 
 type innerItem[V any] struct {
-	value V
-	err   error
-	once  sync.Once
+	value      V
+	err        error
+	once       sync.Once
+	loaded     atomic.Bool
+	loadedAt   time.Time
+	refreshing atomic.Bool
 }
 
 // GetOrLoad retrieves the value associated with the specified key from the cache.
@@ -23,14 +78,75 @@ func (c *Cache[K, V]) GetOrLoad(k K, loadFunc func(k K) (V, error)) (v V, err er
 		panic(errors.New("load function must not be nil"))
 	}
 
+	iItem, _ := c.load(k, loadFunc)
+
+	return iItem.value, iItem.err
+}
+
+// GetOrLoadResult behaves like GetOrLoad and additionally reports whether the value came from the cache.
+// loaded is false only for the caller whose call actually ran loadFunc; callers that waited for
+// a concurrent load of the same key count as cache hits.
+func (c *Cache[K, V]) GetOrLoadResult(k K, loadFunc func(k K) (V, error)) (v V, loaded bool, err error) {
+	if loadFunc == nil {
+		panic(errors.New("load function must not be nil"))
+	}
+
+	iItem, hit := c.load(k, loadFunc)
+
+	return iItem.value, hit, iItem.err
+}
+
+// GetOrLoadRefresh behaves like GetOrLoad, but once an entry is older than ttl it still returns
+// the cached value immediately while reloading it in the background.
+// Only one background reload runs per key at a time, and a failed reload keeps the old value.
+func (c *Cache[K, V]) GetOrLoadRefresh(k K, ttl time.Duration, loadFunc func(k K) (V, error)) (v V, err error) {
+	if loadFunc == nil {
+		panic(errors.New("load function must not be nil"))
+	}
+
+	iItem, _ := c.load(k, loadFunc)
+
+	if time.Since(iItem.loadedAt) > ttl && iItem.refreshing.CompareAndSwap(false, true) {
+		go c.refresh(k, iItem, loadFunc)
+	}
+
+	return iItem.value, iItem.err
+}
+
+func (c *Cache[K, V]) load(k K, loadFunc func(k K) (V, error)) (iItem *innerItem[V], hit bool) {
 	item, _ := c.innerMap.LoadOrStore(k, &innerItem[V]{})
-	iItem := item.(*innerItem[V])
+	iItem = item.(*innerItem[V])
 
+	hit = true
 	iItem.once.Do(func() {
+		c.loadSem.acquire()
+		defer c.loadSem.release()
+
+		hit = false
 		iItem.value, iItem.err = loadFunc(k)
+		iItem.loadedAt = time.Now()
+		iItem.loaded.Store(true)
 	})
 
-	return iItem.value, iItem.err
+	return iItem, hit
+}
+
+func (c *Cache[K, V]) refresh(k K, old *innerItem[V], loadFunc func(k K) (V, error)) {
+	c.loadSem.acquire()
+	value, err := loadFunc(k)
+	c.loadSem.release()
+
+	if err != nil {
+		old.refreshing.Store(false)
+		return
+	}
+
+	fresh := &innerItem[V]{value: value, loadedAt: time.Now()}
+	fresh.once.Do(func() {})
+	fresh.loaded.Store(true)
+
+	// the entry may have been evicted or replaced meanwhile, only swap our own
+	c.innerMap.CompareAndSwap(k, old, fresh)
 }
 
 // Evict removes the entry with the specified key from the cache.
@@ -45,3 +161,86 @@ func (c *Cache[K, V]) Evict(k K) bool {
 func (c *Cache[K, V]) Clear() {
 	c.innerMap = sync.Map{}
 }
+
+// InvalidateFunc evicts all entries whose load has completed and whose key and value match pred.
+// Entries that are still loading are left alone. It returns the number of evicted entries.
+func (c *Cache[K, V]) InvalidateFunc(pred func(k K, v V) bool) int {
+	count := 0
+
+	c.innerMap.Range(func(key, item any) bool {
+		iItem := item.(*innerItem[V])
+		if iItem.loaded.Load() && pred(key.(K), iItem.value) && c.innerMap.CompareAndDelete(key, item) {
+			count++
+		}
+		return true
+	})
+
+	return count
+}
+
+// Len returns the number of entries whose load has completed.
+func (c *Cache[K, V]) Len() int {
+	count := 0
+
+	c.innerMap.Range(func(_, item any) bool {
+		if item.(*innerItem[V]).loaded.Load() {
+			count++
+		}
+		return true
+	})
+
+	return count
+}
+
+// IsEmpty reports whether the cache holds no completed entries.
+// It stops at the first completed entry instead of counting all of them.
+func (c *Cache[K, V]) IsEmpty() bool {
+	empty := true
+
+	c.innerMap.Range(func(_, item any) bool {
+		if item.(*innerItem[V]).loaded.Load() {
+			empty = false
+		}
+		return empty
+	})
+
+	return empty
+}
+
+// CachePair is a key/value pair taken from a Cache.
+type CachePair[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
+// Entries returns a snapshot of all entries that loaded successfully.
+// Entries that are still loading or whose load failed are excluded.
+func (c *Cache[K, V]) Entries() []CachePair[K, V] {
+	entries := make([]CachePair[K, V], 0)
+
+	c.innerMap.Range(func(key, item any) bool {
+		iItem := item.(*innerItem[V])
+		if iItem.loaded.Load() && iItem.err == nil {
+			entries = append(entries, CachePair[K, V]{Key: key.(K), Value: iItem.value})
+		}
+		return true
+	})
+
+	return entries
+}
+
+// Load returns the cached value for k without invoking any loader.
+// It reports false if the entry is absent, still loading, or its load failed.
+func (c *Cache[K, V]) Load(k K) (v V, ok bool) {
+	item, found := c.innerMap.Load(k)
+	if !found {
+		return
+	}
+
+	iItem := item.(*innerItem[V])
+	if !iItem.loaded.Load() || iItem.err != nil {
+		return
+	}
+
+	return iItem.value, true
+}
@@ -2,8 +2,12 @@ package generic
 
 import (
 	"errors"
+	"sort"
+	"strconv"
 	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -181,3 +185,324 @@ func TestCache_Clear(t *testing.T) {
 	}
 
 }
+
+func TestCache_WithMaxConcurrentLoads(t *testing.T) {
+	const limit = 2
+
+	cache := NewCache[int, int](WithMaxConcurrentLoads(limit))
+
+	var mu sync.Mutex
+	loading, maxLoading, calls := 0, 0, 0
+	started := make(chan struct{}, 10)
+	release := make(chan struct{})
+
+	load := func(k int) (int, error) {
+		mu.Lock()
+		calls++
+		loading++
+		if loading > maxLoading {
+			maxLoading = loading
+		}
+		mu.Unlock()
+
+		started <- struct{}{}
+		<-release
+
+		mu.Lock()
+		loading--
+		mu.Unlock()
+		return k * 2, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		for j := 0; j < 3; j++ {
+			wg.Add(1)
+			go func(k int) {
+				defer wg.Done()
+				v, err := cache.GetOrLoad(k, load)
+				assert.NoError(t, err)
+				assert.Equal(t, k*2, v)
+			}(i)
+		}
+	}
+
+	for i := 0; i < limit; i++ {
+		<-started
+	}
+	// give blocked loads a chance to (incorrectly) start
+	time.Sleep(20 * time.Millisecond)
+	mu.Lock()
+	assert.Equal(t, limit, loading)
+	mu.Unlock()
+
+	close(release)
+	wg.Wait()
+
+	assert.LessOrEqual(t, maxLoading, limit)
+	assert.Equal(t, 10, calls)
+}
+
+func TestCache_InvalidateFunc(t *testing.T) {
+	cache := &Cache[string, string]{}
+
+	for _, k := range []string{"a:1", "a:2", "b:1"} {
+		_, _ = cache.GetOrLoad(k, func(k string) (string, error) {
+			return "tenant " + k[:1], nil
+		})
+	}
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	go func() {
+		_, _ = cache.GetOrLoad("a:3", func(k string) (string, error) {
+			close(started)
+			<-release
+			return "tenant a", nil
+		})
+	}()
+	<-started
+
+	count := cache.InvalidateFunc(func(k string, v string) bool {
+		return v == "tenant a"
+	})
+	close(release)
+
+	assert.Equal(t, 2, count)
+
+	reloaded := func(k string) (string, error) {
+		return "reloaded", nil
+	}
+	for k, want := range map[string]string{"a:1": "reloaded", "a:2": "reloaded", "b:1": "tenant b", "a:3": "tenant a"} {
+		v, err := cache.GetOrLoad(k, reloaded)
+		assert.NoError(t, err)
+		assert.Equal(t, want, v, k)
+	}
+}
+
+func TestCache_GetOrLoadRefresh(t *testing.T) {
+	const ttl = 20 * time.Millisecond
+
+	t.Run("serves stale value while refreshing", func(t *testing.T) {
+		cache := &Cache[string, int]{}
+
+		var calls atomic.Int32
+		release := make(chan struct{})
+		load := func(k string) (int, error) {
+			n := calls.Add(1)
+			if n > 1 {
+				<-release
+			}
+			return int(n), nil
+		}
+
+		v, err := cache.GetOrLoadRefresh("k", ttl, load)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, v)
+
+		time.Sleep(2 * ttl)
+
+		// stale: the old value is returned and only one refresh is started
+		for i := 0; i < 5; i++ {
+			v, err = cache.GetOrLoadRefresh("k", ttl, load)
+			assert.NoError(t, err)
+			assert.Equal(t, 1, v)
+		}
+		close(release)
+
+		assert.Eventually(t, func() bool {
+			v, _ := cache.GetOrLoadRefresh("k", time.Hour, load)
+			return v == 2
+		}, time.Second, time.Millisecond)
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("failed refresh keeps old value", func(t *testing.T) {
+		cache := &Cache[string, int]{}
+
+		var calls atomic.Int32
+		load := func(k string) (int, error) {
+			if calls.Add(1) > 1 {
+				return 0, errors.New("backend down")
+			}
+			return 1, nil
+		}
+
+		_, _ = cache.GetOrLoadRefresh("k", ttl, load)
+		time.Sleep(2 * ttl)
+
+		v, err := cache.GetOrLoadRefresh("k", ttl, load)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, v)
+
+		assert.Eventually(t, func() bool {
+			return calls.Load() >= 2
+		}, time.Second, time.Millisecond)
+
+		v, err = cache.GetOrLoadRefresh("k", time.Hour, load)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, v)
+	})
+}
+
+func TestCache_LenAndIsEmpty(t *testing.T) {
+	cache := &Cache[int, int]{}
+	assert.Equal(t, 0, cache.Len())
+	assert.True(t, cache.IsEmpty())
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	go func() {
+		_, _ = cache.GetOrLoad(0, func(k int) (int, error) {
+			close(started)
+			<-release
+			return k, nil
+		})
+	}()
+	<-started
+
+	// an entry still loading doesn't count
+	assert.Equal(t, 0, cache.Len())
+	assert.True(t, cache.IsEmpty())
+
+	for i := 1; i <= 3; i++ {
+		_, _ = cache.GetOrLoad(i, func(k int) (int, error) { return k, nil })
+	}
+	assert.Equal(t, 3, cache.Len())
+	assert.False(t, cache.IsEmpty())
+
+	close(release)
+	assert.Eventually(t, func() bool { return cache.Len() == 4 }, time.Second, time.Millisecond)
+
+	cache.Clear()
+	assert.Equal(t, 0, cache.Len())
+	assert.True(t, cache.IsEmpty())
+}
+
+func TestCache_Entries(t *testing.T) {
+	cache := &Cache[int, string]{}
+	assert.Empty(t, cache.Entries())
+
+	load := func(k int) (string, error) {
+		if k < 0 {
+			return "", errors.New("negative key")
+		}
+		return "v" + strconv.Itoa(k), nil
+	}
+	for _, k := range []int{1, 2, -1} {
+		_, _ = cache.GetOrLoad(k, load)
+	}
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	go func() {
+		_, _ = cache.GetOrLoad(3, func(k int) (string, error) {
+			close(started)
+			<-release
+			return "v3", nil
+		})
+	}()
+	<-started
+	defer close(release)
+
+	entries := cache.Entries()
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Key < entries[j].Key })
+	assert.Equal(t, []CachePair[int, string]{{Key: 1, Value: "v1"}, {Key: 2, Value: "v2"}}, entries)
+}
+
+func TestCache_Load(t *testing.T) {
+	cache := &Cache[string, int]{}
+
+	_, _ = cache.GetOrLoad("ok", func(k string) (int, error) { return 1, nil })
+	_, _ = cache.GetOrLoad("failed", func(k string) (int, error) { return 0, errors.New("load failed") })
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	go func() {
+		_, _ = cache.GetOrLoad("loading", func(k string) (int, error) {
+			close(started)
+			<-release
+			return 3, nil
+		})
+	}()
+	<-started
+	defer close(release)
+
+	testCases := []struct {
+		key     string
+		wantVal int
+		wantOk  bool
+	}{
+		{key: "ok", wantVal: 1, wantOk: true},
+		{key: "failed", wantVal: 0, wantOk: false},
+		{key: "loading", wantVal: 0, wantOk: false},
+		{key: "absent", wantVal: 0, wantOk: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.key, func(t *testing.T) {
+			v, ok := cache.Load(tc.key)
+			assert.Equal(t, tc.wantVal, v)
+			assert.Equal(t, tc.wantOk, ok)
+		})
+	}
+}
+
+func TestCache_GetOrLoadResult(t *testing.T) {
+	cache := &Cache[string, int]{}
+
+	calls := 0
+	load := func(k string) (int, error) {
+		calls++
+		if k == "bad" {
+			return 0, errors.New("load failed")
+		}
+		return len(k), nil
+	}
+
+	testCases := []struct {
+		name       string
+		key        string
+		wantVal    int
+		wantLoaded bool
+		wantErr    error
+	}{
+		{name: "miss", key: "abc", wantVal: 3, wantLoaded: false},
+		{name: "hit", key: "abc", wantVal: 3, wantLoaded: true},
+		{name: "error miss", key: "bad", wantErr: errors.New("load failed"), wantLoaded: false},
+		{name: "error hit", key: "bad", wantErr: errors.New("load failed"), wantLoaded: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			v, loaded, err := cache.GetOrLoadResult(tc.key, load)
+			assert.Equal(t, tc.wantVal, v)
+			assert.Equal(t, tc.wantLoaded, loaded)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+	assert.Equal(t, 2, calls)
+
+	t.Run("concurrent callers share one miss", func(t *testing.T) {
+		cache := &Cache[int, int]{}
+
+		var misses atomic.Int32
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, loaded, _ := cache.GetOrLoadResult(1, func(k int) (int, error) {
+					time.Sleep(5 * time.Millisecond)
+					return k, nil
+				})
+				if !loaded {
+					misses.Add(1)
+				}
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, int32(1), misses.Load())
+	})
+}
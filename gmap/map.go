@@ -1,6 +1,13 @@
 package gmap
 
-import "github.com/expgo/sync"
+import (
+	"fmt"
+	"runtime"
+	stdsync "sync"
+	"unsafe"
+
+	"github.com/expgo/sync"
+)
 
 type Map[K comparable, V any] struct {
 	items map[K]V
@@ -82,12 +89,10 @@ func Swap[K comparable, V any](m *Map[K, V], key K, value V) (previous V, loaded
 	return
 }
 
+// Range calls f for each entry of a point-in-time snapshot of m, see Snapshot.
+// Writes made while f runs, including by f itself, are not visible to the iteration.
 func Range[K comparable, V any](m *Map[K, V], f func(key K, value V) bool) {
-	m.lock.RLock()
-	mm := Clone(m.items)
-	m.lock.RUnlock()
-
-	for key, value := range mm {
+	for key, value := range Snapshot(m) {
 		if !f(key, value) {
 			break
 		}
@@ -102,3 +107,256 @@ func Size[K comparable, V any](m *Map[K, V]) int {
 	})
 	return size
 }
+
+func Increment[K comparable](m *Map[K, int64], key K, delta int64) int64 {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.items[key] += delta
+
+	return m.items[key]
+}
+
+// CompareAndSwapFunc stores new for key if the key is present and eq reports true for its current value.
+// The check and the store happen under the write lock, so eq must not call back into m.
+// Since values are matched by eq rather than by identity, a value that was replaced by
+// an equivalent one in the meantime is indistinguishable from the original (ABA).
+func CompareAndSwapFunc[K comparable, V any](m *Map[K, V], key K, eq func(cur V) bool, new V) (swapped bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	cur, ok := m.items[key]
+	if !ok || !eq(cur) {
+		return false
+	}
+
+	m.items[key] = new
+
+	return true
+}
+
+// RangeParallel calls f for every entry of a snapshot of m, using at most workers goroutines.
+// f must be safe for concurrent use, and entries are visited in no particular order.
+// A workers value <= 0 defaults to GOMAXPROCS.
+func RangeParallel[K comparable, V any](m *Map[K, V], workers int, f func(key K, value V)) {
+	mm := Snapshot(m)
+
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > len(mm) {
+		workers = len(mm)
+	}
+
+	keys := make(chan K)
+	var wg stdsync.WaitGroup
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for key := range keys {
+				f(key, mm[key])
+			}
+		}()
+	}
+
+	for key := range mm {
+		keys <- key
+	}
+	close(keys)
+
+	wg.Wait()
+}
+
+func Pop[K comparable, V any](m *Map[K, V]) (key K, value V, ok bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for key, value = range m.items {
+		delete(m.items, key)
+		return key, value, true
+	}
+
+	return
+}
+
+func Equal[K comparable, V comparable](a, b *Map[K, V]) bool {
+	if a == b {
+		return true
+	}
+
+	// lock in address order so concurrent Equal(a, b) and Equal(b, a) can't deadlock
+	first, second := a, b
+	if uintptr(unsafe.Pointer(first)) > uintptr(unsafe.Pointer(second)) {
+		first, second = second, first
+	}
+
+	first.lock.RLock()
+	defer first.lock.RUnlock()
+	second.lock.RLock()
+	defer second.lock.RUnlock()
+
+	if len(a.items) != len(b.items) {
+		return false
+	}
+
+	for key, value := range a.items {
+		if other, ok := b.items[key]; !ok || other != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Snapshot returns a copy of the entries of m taken under the read lock,
+// so it reflects a single consistent point in time.
+func Snapshot[K comparable, V any](m *Map[K, V]) map[K]V {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	return Clone(m.items)
+}
+
+// TransformToMap converts a snapshot of m into a plain map, passing each entry through f.
+// If f maps several entries to the same key, the last one visited wins; since
+// iteration order is unspecified, so is which entry that is.
+func TransformToMap[K comparable, V1, V2 any](m *Map[K, V1], f func(K, V1) (K, V2)) map[K]V2 {
+	mm := Snapshot(m)
+
+	ret := make(map[K]V2, len(mm))
+	for key, value := range mm {
+		k, v := f(key, value)
+		ret[k] = v
+	}
+
+	return ret
+}
+
+func LoadOrStoreFunc[K comparable, V any](m *Map[K, V], key K, f func() V) (actual V, loaded bool) {
+	m.lock.RLock()
+	actual, loaded = m.items[key]
+	m.lock.RUnlock()
+
+	if loaded {
+		return
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	// another caller may have stored the key while we were waiting for the write lock
+	if actual, loaded = m.items[key]; !loaded {
+		actual = f()
+		m.items[key] = actual
+	}
+
+	return
+}
+
+// CopyInto writes all entries of m into dst, overwriting keys that already exist there.
+// A nil dst is a no-op.
+func CopyInto[K comparable, V any](m *Map[K, V], dst map[K]V) {
+	if dst == nil {
+		return
+	}
+
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	for key, value := range m.items {
+		dst[key] = value
+	}
+}
+
+// LoadAndDeleteIf deletes key and returns its value only if the current value satisfies pred.
+// The check and the delete happen under the write lock, so pred must not call back into m.
+func LoadAndDeleteIf[K comparable, V any](m *Map[K, V], key K, pred func(V) bool) (value V, deleted bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	value, ok := m.items[key]
+	if !ok || !pred(value) {
+		var zero V
+		return zero, false
+	}
+
+	delete(m.items, key)
+
+	return value, true
+}
+
+// WithLock runs f while holding the write lock, giving it direct access to the underlying map
+// so that compound operations across several keys are atomic.
+// f must not call other functions on m, and must not retain items after it returns.
+func WithLock[K comparable, V any](m *Map[K, V], f func(items map[K]V)) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	f(m.items)
+}
+
+// SafeRange behaves like Range, but if f panics the iteration stops and the panic is returned as an error.
+func SafeRange[K comparable, V any](m *Map[K, V], f func(key K, value V) bool) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if e, ok := r.(error); ok {
+				err = fmt.Errorf("range callback panicked: %w", e)
+			} else {
+				err = fmt.Errorf("range callback panicked: %v", r)
+			}
+		}
+	}()
+
+	Range(m, f)
+
+	return nil
+}
+
+func LoadMany[K comparable, V any](m *Map[K, V], keys []K) map[K]V {
+	ret := make(map[K]V, len(keys))
+
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	for _, key := range keys {
+		if value, ok := m.items[key]; ok {
+			ret[key] = value
+		}
+	}
+
+	return ret
+}
+
+// Drain atomically removes all entries from m and returns them.
+func Drain[K comparable, V any](m *Map[K, V]) map[K]V {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	items := m.items
+	m.items = map[K]V{}
+
+	return items
+}
+
+// MergeFunc stores every entry of other into m. For keys present in both maps the stored value
+// is combine(existing, incoming), other keys are copied as-is. A nil other is a no-op.
+// other is snapshotted before m is locked, so the two maps are never locked at the same time.
+func MergeFunc[K comparable, V any](m *Map[K, V], other *Map[K, V], combine func(existing, incoming V) V) {
+	if other == nil {
+		return
+	}
+
+	incoming := Snapshot(other)
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for key, value := range incoming {
+		if existing, ok := m.items[key]; ok {
+			value = combine(existing, value)
+		}
+		m.items[key] = value
+	}
+}
@@ -1,8 +1,11 @@
 package gmap
 
 import (
+	"errors"
 	"github.com/stretchr/testify/assert"
 	"reflect"
+	"strings"
+	"sync"
 	"testing"
 )
 
@@ -298,3 +301,650 @@ func TestSize(t *testing.T) {
 		})
 	}
 }
+
+func TestIncrement(t *testing.T) {
+	t.Run("sequential", func(t *testing.T) {
+		m := NewMap[string, int64]()
+
+		assert.Equal(t, int64(1), Increment(m, "hits", 1))
+		assert.Equal(t, int64(6), Increment(m, "hits", 5))
+		assert.Equal(t, int64(4), Increment(m, "hits", -2))
+		assert.Equal(t, int64(-3), Increment(m, "misses", -3))
+
+		v, ok := Load(m, "hits")
+		assert.True(t, ok)
+		assert.Equal(t, int64(4), v)
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		m := NewMap[string, int64]()
+
+		var wg sync.WaitGroup
+		for i := 0; i < 100; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				Increment(m, "hits", 1)
+			}()
+		}
+		wg.Wait()
+
+		v, _ := Load(m, "hits")
+		assert.Equal(t, int64(100), v)
+	})
+}
+
+func TestCompareAndSwapFunc(t *testing.T) {
+	type config struct {
+		name string
+		tags []string
+	}
+
+	byName := func(name string) func(config) bool {
+		return func(cur config) bool {
+			return cur.name == name
+		}
+	}
+
+	tests := []struct {
+		name      string
+		key       string
+		eq        func(config) bool
+		wantSwap  bool
+		wantValue config
+		wantOk    bool
+	}{
+		{
+			name:      "match swaps",
+			key:       "a",
+			eq:        byName("old"),
+			wantSwap:  true,
+			wantValue: config{name: "new", tags: []string{"x"}},
+			wantOk:    true,
+		},
+		{
+			name:      "mismatch keeps",
+			key:       "a",
+			eq:        byName("other"),
+			wantSwap:  false,
+			wantValue: config{name: "old"},
+			wantOk:    true,
+		},
+		{
+			name:     "absent key",
+			key:      "b",
+			eq:       func(config) bool { return true },
+			wantSwap: false,
+			wantOk:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewMap[string, config]()
+			Store(m, "a", config{name: "old"})
+
+			got := CompareAndSwapFunc(m, tt.key, tt.eq, config{name: "new", tags: []string{"x"}})
+			assert.Equal(t, tt.wantSwap, got)
+
+			v, ok := Load(m, tt.key)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.wantValue, v)
+		})
+	}
+}
+
+func TestRangeParallel(t *testing.T) {
+	tests := []struct {
+		name    string
+		size    int
+		workers int
+	}{
+		{name: "empty map", size: 0, workers: 4},
+		{name: "default workers", size: 100, workers: 0},
+		{name: "single worker", size: 10, workers: 1},
+		{name: "more workers than entries", size: 3, workers: 16},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewMap[int, int]()
+			for i := 0; i < tt.size; i++ {
+				Store(m, i, i*2)
+			}
+
+			var mu sync.Mutex
+			seen := map[int]int{}
+			RangeParallel(m, tt.workers, func(key int, value int) {
+				mu.Lock()
+				defer mu.Unlock()
+				seen[key] = value
+			})
+
+			assert.Len(t, seen, tt.size)
+			for k, v := range seen {
+				assert.Equal(t, k*2, v)
+			}
+		})
+	}
+}
+
+func TestPop(t *testing.T) {
+	t.Run("empty map", func(t *testing.T) {
+		m := NewMap[int, string]()
+		_, _, ok := Pop(m)
+		assert.False(t, ok)
+	})
+
+	t.Run("drain", func(t *testing.T) {
+		m := NewMap[int, string]()
+		Store(m, 1, "one")
+		Store(m, 2, "two")
+
+		popped := map[int]string{}
+		for {
+			k, v, ok := Pop(m)
+			if !ok {
+				break
+			}
+			popped[k] = v
+		}
+
+		assert.Equal(t, map[int]string{1: "one", 2: "two"}, popped)
+		assert.Equal(t, 0, Size(m))
+	})
+
+	t.Run("concurrent pops never share entries", func(t *testing.T) {
+		m := NewMap[int, int]()
+		for i := 0; i < 1000; i++ {
+			Store(m, i, i)
+		}
+
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		counts := map[int]int{}
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for {
+					k, _, ok := Pop(m)
+					if !ok {
+						return
+					}
+					mu.Lock()
+					counts[k]++
+					mu.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+
+		assert.Len(t, counts, 1000)
+		for k, c := range counts {
+			assert.Equal(t, 1, c, "key %d popped more than once", k)
+		}
+	})
+}
+
+func TestEqual(t *testing.T) {
+	newMap := func(kv map[string]int) *Map[string, int] {
+		m := NewMap[string, int]()
+		for k, v := range kv {
+			Store(m, k, v)
+		}
+		return m
+	}
+
+	same := newMap(map[string]int{"a": 1})
+
+	tests := []struct {
+		name string
+		a    *Map[string, int]
+		b    *Map[string, int]
+		want bool
+	}{
+		{name: "both empty", a: newMap(nil), b: newMap(nil), want: true},
+		{name: "same instance", a: same, b: same, want: true},
+		{name: "equal content", a: newMap(map[string]int{"a": 1, "b": 2}), b: newMap(map[string]int{"b": 2, "a": 1}), want: true},
+		{name: "different size", a: newMap(map[string]int{"a": 1}), b: newMap(map[string]int{"a": 1, "b": 2}), want: false},
+		{name: "different value", a: newMap(map[string]int{"a": 1}), b: newMap(map[string]int{"a": 2}), want: false},
+		{name: "different key", a: newMap(map[string]int{"a": 1}), b: newMap(map[string]int{"b": 1}), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Equal(tt.a, tt.b))
+			assert.Equal(t, tt.want, Equal(tt.b, tt.a))
+		})
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	m := NewMap[string, int]()
+	assert.Equal(t, map[string]int{}, Snapshot(m))
+
+	Store(m, "a", 1)
+	Store(m, "b", 2)
+
+	snap := Snapshot(m)
+	assert.Equal(t, map[string]int{"a": 1, "b": 2}, snap)
+
+	Store(m, "c", 3)
+	Delete(m, "a")
+	assert.Equal(t, map[string]int{"a": 1, "b": 2}, snap)
+
+	snap["d"] = 4
+	_, ok := Load(m, "d")
+	assert.False(t, ok)
+}
+
+func TestRangeIteratesSnapshot(t *testing.T) {
+	m := NewMap[int, int]()
+	for i := 0; i < 10; i++ {
+		Store(m, i, i)
+	}
+
+	visited := map[int]int{}
+	Range(m, func(key int, value int) bool {
+		visited[key]++
+		// modifications made during iteration must not affect it
+		Delete(m, (key+1)%10)
+		Store(m, key+100, key)
+		return true
+	})
+
+	assert.Len(t, visited, 10)
+	for k, c := range visited {
+		assert.Equal(t, 1, c, "key %d visited more than once", k)
+	}
+}
+
+func TestTransformToMap(t *testing.T) {
+	tests := []struct {
+		name  string
+		items map[string]int
+		f     func(string, int) (string, string)
+		want  map[string]string
+	}{
+		{
+			name:  "empty map",
+			items: map[string]int{},
+			f:     func(k string, v int) (string, string) { return k, "" },
+			want:  map[string]string{},
+		},
+		{
+			name:  "reshape keys and values",
+			items: map[string]int{"a": 1, "b": 2},
+			f: func(k string, v int) (string, string) {
+				return "key_" + k, strings.Repeat("*", v)
+			},
+			want: map[string]string{"key_a": "*", "key_b": "**"},
+		},
+		{
+			name:  "colliding keys",
+			items: map[string]int{"a": 1, "b": 1},
+			f:     func(k string, v int) (string, string) { return "same", "x" },
+			want:  map[string]string{"same": "x"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewMap[string, int]()
+			for k, v := range tt.items {
+				Store(m, k, v)
+			}
+			assert.Equal(t, tt.want, TransformToMap(m, tt.f))
+		})
+	}
+}
+
+func TestLoadOrStoreFunc(t *testing.T) {
+	t.Run("absent key stores", func(t *testing.T) {
+		m := NewMap[string, int]()
+		actual, loaded := LoadOrStoreFunc(m, "a", func() int { return 1 })
+		assert.Equal(t, 1, actual)
+		assert.False(t, loaded)
+
+		v, ok := Load(m, "a")
+		assert.True(t, ok)
+		assert.Equal(t, 1, v)
+	})
+
+	t.Run("present key skips f", func(t *testing.T) {
+		m := NewMap[string, int]()
+		Store(m, "a", 1)
+		actual, loaded := LoadOrStoreFunc(m, "a", func() int {
+			t.Error("f must not be called for a present key")
+			return 2
+		})
+		assert.Equal(t, 1, actual)
+		assert.True(t, loaded)
+	})
+
+	t.Run("concurrent callers run f once", func(t *testing.T) {
+		m := NewMap[string, int]()
+
+		var mu sync.Mutex
+		calls := 0
+		var wg sync.WaitGroup
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				actual, _ := LoadOrStoreFunc(m, "a", func() int {
+					mu.Lock()
+					defer mu.Unlock()
+					calls++
+					return 42
+				})
+				assert.Equal(t, 42, actual)
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, 1, calls)
+	})
+}
+
+func TestCopyInto(t *testing.T) {
+	tests := []struct {
+		name  string
+		items map[string]int
+		dst   map[string]int
+		want  map[string]int
+	}{
+		{
+			name:  "nil dst",
+			items: map[string]int{"a": 1},
+			dst:   nil,
+			want:  nil,
+		},
+		{
+			name:  "empty map",
+			items: map[string]int{},
+			dst:   map[string]int{"x": 9},
+			want:  map[string]int{"x": 9},
+		},
+		{
+			name:  "merge and overwrite",
+			items: map[string]int{"a": 1, "b": 2},
+			dst:   map[string]int{"b": 0, "c": 3},
+			want:  map[string]int{"a": 1, "b": 2, "c": 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewMap[string, int]()
+			for k, v := range tt.items {
+				Store(m, k, v)
+			}
+			CopyInto(m, tt.dst)
+			assert.Equal(t, tt.want, tt.dst)
+		})
+	}
+}
+
+func TestLoadAndDeleteIf(t *testing.T) {
+	expired := func(v int) bool { return v < 0 }
+
+	tests := []struct {
+		name        string
+		key         string
+		wantValue   int
+		wantDeleted bool
+		wantExists  bool
+	}{
+		{name: "predicate matches", key: "expired", wantValue: -1, wantDeleted: true, wantExists: false},
+		{name: "predicate does not match", key: "active", wantValue: 0, wantDeleted: false, wantExists: true},
+		{name: "absent key", key: "missing", wantValue: 0, wantDeleted: false, wantExists: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewMap[string, int]()
+			Store(m, "expired", -1)
+			Store(m, "active", 5)
+
+			value, deleted := LoadAndDeleteIf(m, tt.key, expired)
+			assert.Equal(t, tt.wantValue, value)
+			assert.Equal(t, tt.wantDeleted, deleted)
+
+			_, exists := Load(m, tt.key)
+			assert.Equal(t, tt.wantExists, exists)
+		})
+	}
+}
+
+func TestWithLock(t *testing.T) {
+	t.Run("move value between keys", func(t *testing.T) {
+		m := NewMap[string, int]()
+		Store(m, "from", 1)
+
+		WithLock(m, func(items map[string]int) {
+			items["to"] = items["from"]
+			delete(items, "from")
+		})
+
+		assert.Equal(t, map[string]int{"to": 1}, Snapshot(m))
+	})
+
+	t.Run("compound operations are atomic", func(t *testing.T) {
+		m := NewMap[string, int]()
+		Store(m, "a", 100)
+		Store(m, "b", 0)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 100; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				WithLock(m, func(items map[string]int) {
+					items["a"]--
+					items["b"]++
+				})
+			}()
+			go func() {
+				defer wg.Done()
+				snap := Snapshot(m)
+				assert.Equal(t, 100, snap["a"]+snap["b"])
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, map[string]int{"a": 0, "b": 100}, Snapshot(m))
+	})
+}
+
+func TestSafeRange(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	tests := []struct {
+		name    string
+		f       func(key int, value int) bool
+		wantErr string
+		isBoom  bool
+	}{
+		{
+			name:    "no panic",
+			f:       func(key int, value int) bool { return true },
+			wantErr: "",
+		},
+		{
+			name:    "panic with value",
+			f:       func(key int, value int) bool { panic("bad entry") },
+			wantErr: "range callback panicked: bad entry",
+		},
+		{
+			name:    "panic with error",
+			f:       func(key int, value int) bool { panic(errBoom) },
+			wantErr: "range callback panicked: boom",
+			isBoom:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewMap[int, int]()
+			Store(m, 1, 1)
+			Store(m, 2, 2)
+
+			err := SafeRange(m, tt.f)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+			assert.Equal(t, tt.isBoom, errors.Is(err, errBoom))
+		})
+	}
+}
+
+func TestLoadMany(t *testing.T) {
+	m := NewMap[string, int]()
+	Store(m, "a", 1)
+	Store(m, "b", 0)
+	Store(m, "c", 3)
+
+	tests := []struct {
+		name string
+		keys []string
+		want map[string]int
+	}{
+		{name: "nil keys", keys: nil, want: map[string]int{}},
+		{name: "all present", keys: []string{"a", "c"}, want: map[string]int{"a": 1, "c": 3}},
+		{name: "absent keys omitted", keys: []string{"a", "x", "b"}, want: map[string]int{"a": 1, "b": 0}},
+		{name: "none present", keys: []string{"x", "y"}, want: map[string]int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, LoadMany(m, tt.keys))
+		})
+	}
+}
+
+func TestDrain(t *testing.T) {
+	t.Run("empty map", func(t *testing.T) {
+		m := NewMap[string, int]()
+		assert.Equal(t, map[string]int{}, Drain(m))
+	})
+
+	t.Run("removes all entries", func(t *testing.T) {
+		m := NewMap[string, int]()
+		Store(m, "a", 1)
+		Store(m, "b", 2)
+
+		assert.Equal(t, map[string]int{"a": 1, "b": 2}, Drain(m))
+		assert.Equal(t, 0, Size(m))
+
+		Store(m, "c", 3)
+		assert.Equal(t, map[string]int{"c": 3}, Drain(m))
+	})
+
+	t.Run("concurrent writes are drained exactly once", func(t *testing.T) {
+		m := NewMap[int, int]()
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for i := 0; i < 1000; i++ {
+				Store(m, i, i)
+			}
+		}()
+
+		counts := map[int]int{}
+		for finished := false; !finished; {
+			select {
+			case <-done:
+				finished = true
+			default:
+			}
+			for k := range Drain(m) {
+				counts[k]++
+			}
+		}
+
+		assert.Len(t, counts, 1000)
+		for k, c := range counts {
+			assert.Equal(t, 1, c, "key %d drained more than once", k)
+		}
+	})
+}
+
+func TestMergeFunc(t *testing.T) {
+	newMap := func(kv map[string]int) *Map[string, int] {
+		m := NewMap[string, int]()
+		for k, v := range kv {
+			Store(m, k, v)
+		}
+		return m
+	}
+
+	tests := []struct {
+		name  string
+		m     map[string]int
+		other *Map[string, int]
+		want  map[string]int
+		calls int
+	}{
+		{
+			name:  "nil other",
+			m:     map[string]int{"a": 1},
+			other: nil,
+			want:  map[string]int{"a": 1},
+			calls: 0,
+		},
+		{
+			name:  "disjoint",
+			m:     map[string]int{"a": 1},
+			other: newMap(map[string]int{"b": 2}),
+			want:  map[string]int{"a": 1, "b": 2},
+			calls: 0,
+		},
+		{
+			name:  "colliding keys are combined",
+			m:     map[string]int{"a": 1, "b": 2},
+			other: newMap(map[string]int{"b": 3, "c": 4}),
+			want:  map[string]int{"a": 1, "b": 5, "c": 4},
+			calls: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newMap(tt.m)
+
+			calls := 0
+			MergeFunc(m, tt.other, func(existing, incoming int) int {
+				calls++
+				return existing + incoming
+			})
+
+			assert.Equal(t, tt.want, Snapshot(m))
+			assert.Equal(t, tt.calls, calls)
+		})
+	}
+
+	t.Run("concurrent opposite merges do not deadlock", func(t *testing.T) {
+		a := newMap(map[string]int{"x": 1})
+		b := newMap(map[string]int{"x": 1})
+		sum := func(existing, incoming int) int { return existing + incoming }
+
+		var wg sync.WaitGroup
+		for i := 0; i < 50; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				MergeFunc(a, b, sum)
+			}()
+			go func() {
+				defer wg.Done()
+				MergeFunc(b, a, sum)
+			}()
+		}
+		wg.Wait()
+	})
+}
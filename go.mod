@@ -5,6 +5,7 @@ go 1.20
 require (
 	github.com/expgo/sync v0.0.0-20240416034417-7c4de7477076
 	github.com/stretchr/testify v1.9.0
+	golang.org/x/exp v0.0.0-20240416160154-fe59bbe5cc7f
 )
 
 require (
@@ -0,0 +1,19 @@
+package generic
+
+// Key2 is a comparable two-part key, e.g. for a Cache keyed by (userID, resourceID):
+//
+//	cache := &Cache[Key2[string, int], V]{}
+//	v, err := cache.GetOrLoad(Key2[string, int]{First: userID, Second: resourceID}, load)
+//
+// Unlike concatenating the parts into a string, distinct pairs can never collide.
+type Key2[A, B comparable] struct {
+	First  A
+	Second B
+}
+
+// Key3 is a comparable three-part key, see Key2.
+type Key3[A, B, C comparable] struct {
+	First  A
+	Second B
+	Third  C
+}
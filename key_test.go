@@ -0,0 +1,53 @@
+package generic
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKey2_AsCacheKey(t *testing.T) {
+	cache := &Cache[Key2[string, string], string]{}
+
+	load := func(k Key2[string, string]) (string, error) {
+		return fmt.Sprintf("%s/%s", k.First, k.Second), nil
+	}
+
+	// these pairs would collide if naively concatenated with "/" or ""
+	keys := []Key2[string, string]{
+		{First: "a/b", Second: "c"},
+		{First: "a", Second: "b/c"},
+		{First: "ab", Second: "c"},
+		{First: "a", Second: "bc"},
+	}
+	for _, k := range keys {
+		_, err := cache.GetOrLoad(k, load)
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, len(keys), cache.Len())
+
+	v, ok := cache.Load(Key2[string, string]{First: "a", Second: "b/c"})
+	assert.True(t, ok)
+	assert.Equal(t, "a/b/c", v)
+}
+
+func TestKey3_AsCacheKey(t *testing.T) {
+	cache := &Cache[Key3[string, int, bool], int]{}
+
+	calls := 0
+	load := func(k Key3[string, int, bool]) (int, error) {
+		calls++
+		return k.Second, nil
+	}
+
+	for i := 0; i < 2; i++ {
+		v, err := cache.GetOrLoad(Key3[string, int, bool]{First: "u", Second: 1, Third: true}, load)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, v)
+	}
+	_, _ = cache.GetOrLoad(Key3[string, int, bool]{First: "u", Second: 1, Third: false}, load)
+
+	assert.Equal(t, 2, calls)
+}
@@ -0,0 +1,34 @@
+package generic
+
+import (
+	"errors"
+	"sync"
+)
+
+// Lazy holds a value that is initialized on first use.
+type Lazy[T any] struct {
+	init  func() (T, error)
+	value T
+	err   error
+	once  sync.Once
+}
+
+// NewLazy creates a Lazy whose value is produced by init on the first call to Get.
+func NewLazy[T any](init func() (T, error)) *Lazy[T] {
+	if init == nil {
+		panic(errors.New("init function must not be nil"))
+	}
+
+	return &Lazy[T]{init: init}
+}
+
+// Get runs the init function exactly once and returns its result.
+// Subsequent calls return the same value and error without running init again,
+// so an init error is cached as well.
+func (l *Lazy[T]) Get() (T, error) {
+	l.once.Do(func() {
+		l.value, l.err = l.init()
+	})
+
+	return l.value, l.err
+}
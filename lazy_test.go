@@ -0,0 +1,81 @@
+package generic
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLazy_Get(t *testing.T) {
+	testCases := []struct {
+		name        string
+		init        func() (string, error)
+		expectedVal string
+		expectedErr error
+	}{
+		{
+			name: "value",
+			init: func() (string, error) {
+				return "compiled", nil
+			},
+			expectedVal: "compiled",
+		},
+		{
+			name: "error is cached",
+			init: func() (string, error) {
+				return "", errors.New("init failed")
+			},
+			expectedErr: errors.New("init failed"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			calls := 0
+			lazy := NewLazy(func() (string, error) {
+				calls++
+				return tc.init()
+			})
+
+			for i := 0; i < 3; i++ {
+				val, err := lazy.Get()
+				assert.Equal(t, tc.expectedVal, val)
+				assert.Equal(t, tc.expectedErr, err)
+			}
+			assert.Equal(t, 1, calls)
+		})
+	}
+}
+
+func TestLazy_GetConcurrent(t *testing.T) {
+	var mu sync.Mutex
+	calls := 0
+	lazy := NewLazy(func() (int, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls++
+		return 42, nil
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			val, err := lazy.Get()
+			assert.NoError(t, err)
+			assert.Equal(t, 42, val)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, calls)
+}
+
+func TestNewLazy_NilInit(t *testing.T) {
+	assert.PanicsWithError(t, "init function must not be nil", func() {
+		NewLazy[int](nil)
+	})
+}
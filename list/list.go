@@ -1,5 +1,12 @@
 package list
 
+import (
+	"errors"
+	"sort"
+)
+
+var ErrIndexOutOfRange = errors.New("index out of range")
+
 func Contains[E comparable](s []E, e E) bool {
 	for _, ee := range s {
 		if ee == e {
@@ -45,7 +52,7 @@ func DeleteFunc[E any](s []E, e E, matchFunc func(E) bool) ([]E, bool) {
 	return s, false
 }
 
-func Filter[E comparable](s []E, matchFunc func(E) bool) []E {
+func Filter[E any](s []E, matchFunc func(E) bool) []E {
 	ret := make([]E, 0, len(s))
 
 	for _, ee := range s {
@@ -56,3 +63,150 @@ func Filter[E comparable](s []E, matchFunc func(E) bool) []E {
 
 	return ret
 }
+
+func InsertAll[E any](s []E, idx int, elems ...E) ([]E, bool) {
+	if idx < 0 || idx > len(s) {
+		return s, false
+	}
+
+	ret := make([]E, 0, len(s)+len(elems))
+	ret = append(ret, s[:idx]...)
+	ret = append(ret, elems...)
+
+	return append(ret, s[idx:]...), true
+}
+
+func RotateLeft[E any](s []E, k int) {
+	n := len(s)
+	if n <= 1 {
+		return
+	}
+
+	k %= n
+	if k < 0 {
+		k += n
+	}
+	if k == 0 {
+		return
+	}
+
+	reverse(s[:k])
+	reverse(s[k:])
+	reverse(s)
+}
+
+func RotateRight[E any](s []E, k int) {
+	if len(s) <= 1 {
+		return
+	}
+
+	RotateLeft(s, len(s)-k%len(s))
+}
+
+func reverse[E any](s []E) {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+}
+
+func Fold[E, A any](s []E, init A, acc func(A, E) A) A {
+	ret := init
+	for _, ee := range s {
+		ret = acc(ret, ee)
+	}
+
+	return ret
+}
+
+func FoldRight[E, A any](s []E, init A, acc func(E, A) A) A {
+	ret := init
+	for i := len(s) - 1; i >= 0; i-- {
+		ret = acc(s[i], ret)
+	}
+
+	return ret
+}
+
+func Count[E comparable](s []E, e E) int {
+	count := 0
+	for _, ee := range s {
+		if ee == e {
+			count++
+		}
+	}
+
+	return count
+}
+
+func CountFunc[E any](s []E, matchFunc func(E) bool) int {
+	count := 0
+	for _, ee := range s {
+		if matchFunc(ee) {
+			count++
+		}
+	}
+
+	return count
+}
+
+func SortFunc[E any](s []E, less func(a, b E) bool) {
+	if len(s) < 2 {
+		return
+	}
+
+	sort.Slice(s, func(i, j int) bool {
+		return less(s[i], s[j])
+	})
+}
+
+// At returns the element at idx, where negative indices count from the end, so -1 is the last element.
+func At[E any](s []E, idx int) (E, error) {
+	if idx < 0 {
+		idx += len(s)
+	}
+
+	if idx < 0 || idx >= len(s) {
+		var zero E
+		return zero, ErrIndexOutOfRange
+	}
+
+	return s[idx], nil
+}
+
+func RemoveFirst[E any](s []E) ([]E, E, bool) {
+	if len(s) == 0 {
+		var zero E
+		return s, zero, false
+	}
+
+	return s[1:], s[0], true
+}
+
+func RemoveLast[E any](s []E) ([]E, E, bool) {
+	if len(s) == 0 {
+		var zero E
+		return s, zero, false
+	}
+
+	return s[:len(s)-1], s[len(s)-1], true
+}
+
+func Indices[E any](s []E) []int {
+	ret := make([]int, len(s))
+	for i := range ret {
+		ret[i] = i
+	}
+
+	return ret
+}
+
+func RemoveAt[E any](s []E, idx int) ([]E, bool) {
+	if idx < 0 || idx >= len(s) {
+		return s, false
+	}
+
+	ret := make([]E, 0, len(s))
+	ret = append(ret, s...)
+
+	return append(ret[:idx], ret[idx+1:]...), true
+}
@@ -231,3 +231,303 @@ func TestFilter(t *testing.T) {
 		})
 	}
 }
+
+func TestInsertAll(t *testing.T) {
+	tests := []struct {
+		name     string
+		list     []int
+		idx      int
+		elems    []int
+		expected []int
+		ok       bool
+	}{
+		{"insert into empty list", []int{}, 0, []int{1, 2}, []int{1, 2}, true},
+		{"insert at head", []int{3, 4}, 0, []int{1, 2}, []int{1, 2, 3, 4}, true},
+		{"insert in middle", []int{1, 4}, 1, []int{2, 3}, []int{1, 2, 3, 4}, true},
+		{"insert at tail", []int{1, 2}, 2, []int{3, 4}, []int{1, 2, 3, 4}, true},
+		{"insert nothing", []int{1, 2}, 1, nil, []int{1, 2}, true},
+		{"negative index", []int{1, 2}, -1, []int{3}, []int{1, 2}, false},
+		{"index out of range", []int{1, 2}, 3, []int{3}, []int{1, 2}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orig := append([]int{}, tt.list...)
+			got, ok := InsertAll(tt.list, tt.idx, tt.elems...)
+			if ok != tt.ok {
+				t.Errorf("InsertAll() ok = %v, want %v", ok, tt.ok)
+			}
+			if !compareSlices(got, tt.expected) {
+				t.Errorf("InsertAll() = %v, want %v", got, tt.expected)
+			}
+			if !compareSlices(tt.list, orig) {
+				t.Errorf("InsertAll() modified the original list: %v", tt.list)
+			}
+		})
+	}
+}
+
+func TestRotate(t *testing.T) {
+	tests := []struct {
+		name      string
+		list      []int
+		k         int
+		wantLeft  []int
+		wantRight []int
+	}{
+		{"empty list", []int{}, 3, []int{}, []int{}},
+		{"single element", []int{1}, 5, []int{1}, []int{1}},
+		{"zero", []int{1, 2, 3}, 0, []int{1, 2, 3}, []int{1, 2, 3}},
+		{"by one", []int{1, 2, 3, 4}, 1, []int{2, 3, 4, 1}, []int{4, 1, 2, 3}},
+		{"by size", []int{1, 2, 3}, 3, []int{1, 2, 3}, []int{1, 2, 3}},
+		{"modulo size", []int{1, 2, 3, 4}, 6, []int{3, 4, 1, 2}, []int{3, 4, 1, 2}},
+		{"negative", []int{1, 2, 3, 4}, -1, []int{4, 1, 2, 3}, []int{2, 3, 4, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			left := append([]int{}, tt.list...)
+			RotateLeft(left, tt.k)
+			if !compareSlices(left, tt.wantLeft) {
+				t.Errorf("RotateLeft() = %v, want %v", left, tt.wantLeft)
+			}
+
+			right := append([]int{}, tt.list...)
+			RotateRight(right, tt.k)
+			if !compareSlices(right, tt.wantRight) {
+				t.Errorf("RotateRight() = %v, want %v", right, tt.wantRight)
+			}
+		})
+	}
+}
+
+func TestFold(t *testing.T) {
+	tests := []struct {
+		name      string
+		list      []string
+		wantLeft  string
+		wantRight string
+	}{
+		{"empty list", []string{}, "", ""},
+		{"single element", []string{"a"}, "a", "a"},
+		{"multiple elements", []string{"a", "b", "c"}, "abc", "cba"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			left := Fold(tt.list, "", func(acc string, e string) string { return acc + e })
+			if left != tt.wantLeft {
+				t.Errorf("Fold() = %v, want %v", left, tt.wantLeft)
+			}
+
+			right := FoldRight(tt.list, "", func(e string, acc string) string { return acc + e })
+			if right != tt.wantRight {
+				t.Errorf("FoldRight() = %v, want %v", right, tt.wantRight)
+			}
+		})
+	}
+}
+
+func TestCount(t *testing.T) {
+	tests := []struct {
+		name     string
+		list     []int
+		element  int
+		expected int
+	}{
+		{"empty list", []int{}, 1, 0},
+		{"no match", []int{2, 3}, 1, 0},
+		{"single match", []int{1, 2, 3}, 2, 1},
+		{"multiple matches", []int{1, 2, 1, 3, 1}, 1, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Count(tt.list, tt.element); got != tt.expected {
+				t.Errorf("Count() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCountFunc(t *testing.T) {
+	isEven := func(e int) bool { return e%2 == 0 }
+
+	tests := []struct {
+		name     string
+		list     []int
+		expected int
+	}{
+		{"empty list", []int{}, 0},
+		{"no match", []int{1, 3, 5}, 0},
+		{"some match", []int{1, 2, 3, 4, 6}, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CountFunc(tt.list, isEven); got != tt.expected {
+				t.Errorf("CountFunc() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSortFunc(t *testing.T) {
+	tests := []struct {
+		name     string
+		list     []int
+		less     func(a, b int) bool
+		expected []int
+	}{
+		{"empty list", []int{}, func(a, b int) bool { return a < b }, []int{}},
+		{"single element", []int{1}, func(a, b int) bool { return a < b }, []int{1}},
+		{"ascending", []int{3, 1, 2}, func(a, b int) bool { return a < b }, []int{1, 2, 3}},
+		{"descending", []int{3, 1, 2}, func(a, b int) bool { return a > b }, []int{3, 2, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SortFunc(tt.list, tt.less)
+			if !compareSlices(tt.list, tt.expected) {
+				t.Errorf("SortFunc() = %v, want %v", tt.list, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAt(t *testing.T) {
+	tests := []struct {
+		name     string
+		list     []int
+		idx      int
+		expected int
+		err      error
+	}{
+		{"empty list", []int{}, 0, 0, ErrIndexOutOfRange},
+		{"first", []int{1, 2, 3}, 0, 1, nil},
+		{"last", []int{1, 2, 3}, 2, 3, nil},
+		{"negative last", []int{1, 2, 3}, -1, 3, nil},
+		{"negative first", []int{1, 2, 3}, -3, 1, nil},
+		{"out of range", []int{1, 2, 3}, 3, 0, ErrIndexOutOfRange},
+		{"negative out of range", []int{1, 2, 3}, -4, 0, ErrIndexOutOfRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := At(tt.list, tt.idx)
+			if err != tt.err {
+				t.Errorf("At() error = %v, want %v", err, tt.err)
+			}
+			if got != tt.expected {
+				t.Errorf("At() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRemoveFirstAndLast(t *testing.T) {
+	tests := []struct {
+		name      string
+		list      []int
+		wantFirst int
+		restFirst []int
+		wantLast  int
+		restLast  []int
+		ok        bool
+	}{
+		{"empty list", []int{}, 0, []int{}, 0, []int{}, false},
+		{"single element", []int{1}, 1, []int{}, 1, []int{}, true},
+		{"multiple elements", []int{1, 2, 3}, 1, []int{2, 3}, 3, []int{1, 2}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rest, first, ok := RemoveFirst(tt.list)
+			if ok != tt.ok || first != tt.wantFirst || !compareSlices(rest, tt.restFirst) {
+				t.Errorf("RemoveFirst() = (%v, %v, %v), want (%v, %v, %v)", rest, first, ok, tt.restFirst, tt.wantFirst, tt.ok)
+			}
+
+			rest, last, ok := RemoveLast(tt.list)
+			if ok != tt.ok || last != tt.wantLast || !compareSlices(rest, tt.restLast) {
+				t.Errorf("RemoveLast() = (%v, %v, %v), want (%v, %v, %v)", rest, last, ok, tt.restLast, tt.wantLast, tt.ok)
+			}
+		})
+	}
+}
+
+func TestIndices(t *testing.T) {
+	tests := []struct {
+		name     string
+		list     []string
+		expected []int
+	}{
+		{"empty list", []string{}, []int{}},
+		{"single element", []string{"a"}, []int{0}},
+		{"multiple elements", []string{"a", "b", "c"}, []int{0, 1, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Indices(tt.list); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Indices() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRemoveAt(t *testing.T) {
+	tests := []struct {
+		name     string
+		list     []int
+		idx      int
+		expected []int
+		ok       bool
+	}{
+		{"empty list", []int{}, 0, []int{}, false},
+		{"first", []int{1, 2, 3}, 0, []int{2, 3}, true},
+		{"middle", []int{1, 2, 3}, 1, []int{1, 3}, true},
+		{"last", []int{1, 2, 3}, 2, []int{1, 2}, true},
+		{"negative index", []int{1, 2, 3}, -1, []int{1, 2, 3}, false},
+		{"out of range", []int{1, 2, 3}, 3, []int{1, 2, 3}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orig := append([]int{}, tt.list...)
+			got, ok := RemoveAt(tt.list, tt.idx)
+			if ok != tt.ok || !compareSlices(got, tt.expected) {
+				t.Errorf("RemoveAt() = (%v, %v), want (%v, %v)", got, ok, tt.expected, tt.ok)
+			}
+			if !compareSlices(tt.list, orig) {
+				t.Errorf("RemoveAt() modified the original list: %v", tt.list)
+			}
+		})
+	}
+}
+
+func TestNonComparableElements(t *testing.T) {
+	type config struct {
+		name string
+		tags []string
+	}
+
+	configs := []config{
+		{name: "a", tags: []string{"x"}},
+		{name: "b"},
+		{name: "c", tags: []string{"y", "z"}},
+	}
+	tagged := func(c config) bool { return len(c.tags) > 0 }
+
+	if got := Filter(configs, tagged); len(got) != 2 || got[0].name != "a" || got[1].name != "c" {
+		t.Errorf("Filter() = %v", got)
+	}
+	if !ContainsFunc(configs, config{}, func(c config) bool { return c.name == "b" }) {
+		t.Errorf("ContainsFunc() = false, want true")
+	}
+	if got, ok := RemoveAt(configs, 1); !ok || len(got) != 2 || got[1].name != "c" {
+		t.Errorf("RemoveAt() = (%v, %v)", got, ok)
+	}
+	if got := CountFunc(configs, tagged); got != 2 {
+		t.Errorf("CountFunc() = %v, want 2", got)
+	}
+}
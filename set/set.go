@@ -1,6 +1,16 @@
 package set
 
-import "github.com/expgo/generic/list"
+import (
+	"fmt"
+	"hash/maphash"
+	"reflect"
+
+	"github.com/expgo/generic/list"
+	"github.com/expgo/generic/stream"
+	"golang.org/x/exp/constraints"
+)
+
+var hashSeed = maphash.MakeSeed()
 
 func Add[E comparable](s []E, e E) ([]E, bool) {
 	if list.Contains(s, e) {
@@ -19,3 +29,269 @@ func AddFunc[E any](s []E, e E, matchFunc func(E) bool) ([]E, bool) {
 	s = append(s, e)
 	return s, true
 }
+
+func Collect[E comparable](s []E) []E {
+	return stream.Distinct(s)
+}
+
+func Diff[E comparable](a, b []E) (onlyA, onlyB, both []E) {
+	inA := make(map[E]struct{}, len(a))
+	for _, e := range a {
+		inA[e] = struct{}{}
+	}
+	inB := make(map[E]struct{}, len(b))
+	for _, e := range b {
+		inB[e] = struct{}{}
+	}
+
+	onlyA, onlyB, both = []E{}, []E{}, []E{}
+	seen := make(map[E]struct{}, len(a)+len(b))
+
+	for _, e := range a {
+		if _, ok := seen[e]; ok {
+			continue
+		}
+		seen[e] = struct{}{}
+
+		if _, ok := inB[e]; ok {
+			both = append(both, e)
+		} else {
+			onlyA = append(onlyA, e)
+		}
+	}
+
+	for _, e := range b {
+		if _, ok := seen[e]; ok {
+			continue
+		}
+		seen[e] = struct{}{}
+
+		if _, ok := inA[e]; !ok {
+			onlyB = append(onlyB, e)
+		}
+	}
+
+	return
+}
+
+// Equal reports whether a and b contain the same distinct elements, regardless of order
+// and multiplicity.
+func Equal[E comparable](a, b []E) bool {
+	inA := make(map[E]struct{}, len(a))
+	for _, e := range a {
+		inA[e] = struct{}{}
+	}
+	inB := make(map[E]struct{}, len(b))
+	for _, e := range b {
+		inB[e] = struct{}{}
+	}
+
+	if len(inA) != len(inB) {
+		return false
+	}
+
+	for e := range inA {
+		if _, ok := inB[e]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Hash returns an order-independent hash of the distinct elements in s.
+// Sets that are Equal produce equal hashes within the same process, with float zeros
+// normalised so that 0 and -0 match. Floats nested inside structs or arrays are hashed
+// as formatted, so -0 there still differs from 0. Different sets may collide, so
+// matching hashes must be confirmed with Equal.
+func Hash[E comparable](s []E) uint64 {
+	var ret uint64
+
+	var h maphash.Hash
+	h.SetSeed(hashSeed)
+	seen := make(map[E]struct{}, len(s))
+	for _, e := range s {
+		if _, ok := seen[e]; ok {
+			continue
+		}
+		seen[e] = struct{}{}
+
+		h.Reset()
+		_, _ = fmt.Fprintf(&h, "%#v", hashKey(e))
+		ret ^= h.Sum64()
+	}
+
+	return ret
+}
+
+// hashKey maps float and complex zeros to positive zero, so values that are == format alike.
+func hashKey(e any) any {
+	v := reflect.ValueOf(e)
+	switch v.Kind() {
+	case reflect.Float32, reflect.Float64:
+		if v.Float() == 0 {
+			return reflect.Zero(v.Type()).Interface()
+		}
+	case reflect.Complex64, reflect.Complex128:
+		re, im := real(v.Complex()), imag(v.Complex())
+		if re == 0 || im == 0 {
+			if re == 0 {
+				re = 0
+			}
+			if im == 0 {
+				im = 0
+			}
+			z := reflect.New(v.Type()).Elem()
+			z.SetComplex(complex(re, im))
+			return z.Interface()
+		}
+	}
+	return e
+}
+
+func UnionAll[E comparable](ss ...[]E) []E {
+	ret := make([]E, 0)
+	seen := make(map[E]struct{})
+
+	for _, s := range ss {
+		for _, e := range s {
+			if _, ok := seen[e]; !ok {
+				seen[e] = struct{}{}
+				ret = append(ret, e)
+			}
+		}
+	}
+
+	return ret
+}
+
+// IntersectionAll returns the elements present in every slice, ordered as in the first slice.
+// The intersection of zero slices is empty.
+func IntersectionAll[E comparable](ss ...[]E) []E {
+	ret := make([]E, 0)
+	if len(ss) == 0 {
+		return ret
+	}
+
+	counts := make(map[E]int)
+	for _, s := range ss {
+		seen := make(map[E]struct{}, len(s))
+		for _, e := range s {
+			if _, ok := seen[e]; !ok {
+				seen[e] = struct{}{}
+				counts[e]++
+			}
+		}
+	}
+
+	for _, e := range ss[0] {
+		if counts[e] == len(ss) {
+			ret = append(ret, e)
+			counts[e] = 0
+		}
+	}
+
+	return ret
+}
+
+// RemoveIf removes the elements matching matchFunc in place and returns the shrunk set
+// together with the number of removed elements.
+func RemoveIf[E any](s []E, matchFunc func(E) bool) ([]E, int) {
+	n := 0
+	for _, e := range s {
+		if !matchFunc(e) {
+			s[n] = e
+			n++
+		}
+	}
+
+	var zero E
+	for i := n; i < len(s); i++ {
+		s[i] = zero
+	}
+
+	return s[:n], len(s) - n
+}
+
+func IntersectionSize[E comparable](a, b []E) int {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+
+	inB := make(map[E]struct{}, len(b))
+	for _, e := range b {
+		inB[e] = struct{}{}
+	}
+
+	count := 0
+	for _, e := range a {
+		if _, ok := inB[e]; ok {
+			delete(inB, e)
+			count++
+		}
+	}
+
+	return count
+}
+
+// Changes returns the elements to add to and remove from s to make it equal to target.
+func Changes[E comparable](s, target []E) (added, removed []E) {
+	removed, added, _ = Diff(s, target)
+	return
+}
+
+func AddAll[E comparable](s []E, other []E) ([]E, int) {
+	seen := make(map[E]struct{}, len(s)+len(other))
+	for _, e := range s {
+		seen[e] = struct{}{}
+	}
+
+	count := 0
+	for _, e := range other {
+		if _, ok := seen[e]; !ok {
+			seen[e] = struct{}{}
+			s = append(s, e)
+			count++
+		}
+	}
+
+	return s, count
+}
+
+// RangeSorted calls f for each element of s in the order given by less, stopping when f returns false.
+// It sorts a copy, so s itself keeps its order.
+func RangeSorted[E any](s []E, less func(a, b E) bool, f func(E) bool) {
+	for _, e := range ToSortedSlice(s, less) {
+		if !f(e) {
+			break
+		}
+	}
+}
+
+// JaccardSimilarity returns |a∩b| / |a∪b|. Two empty sets have a similarity of 0.
+func JaccardSimilarity[E comparable](a, b []E) float64 {
+	intersection := IntersectionSize(a, b)
+	union := distinctCount(a) + distinctCount(b) - intersection
+	if union == 0 {
+		return 0
+	}
+
+	return float64(intersection) / float64(union)
+}
+
+func distinctCount[E comparable](s []E) int {
+	seen := make(map[E]struct{}, len(s))
+	for _, e := range s {
+		seen[e] = struct{}{}
+	}
+
+	return len(seen)
+}
+
+func ToSortedSlice[E any](s []E, less func(a, b E) bool) []E {
+	return stream.SortFunc(s, less)
+}
+
+func SortedSlice[E constraints.Ordered](s []E) []E {
+	return stream.Sort(s)
+}
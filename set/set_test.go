@@ -2,6 +2,7 @@ package set
 
 import (
 	"github.com/stretchr/testify/assert"
+	"math"
 	"reflect"
 	"testing"
 )
@@ -93,3 +94,368 @@ func TestAddFunc(t *testing.T) {
 		})
 	}
 }
+
+func TestCollect(t *testing.T) {
+	tests := []struct {
+		name string
+		s    []int
+		want []int
+	}{
+		{
+			name: "EmptySlice",
+			s:    []int{},
+			want: []int{},
+		},
+		{
+			name: "NoDuplicates",
+			s:    []int{3, 1, 2},
+			want: []int{3, 1, 2},
+		},
+		{
+			name: "WithDuplicates",
+			s:    []int{1, 2, 1, 3, 2, 1},
+			want: []int{1, 2, 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Collect(tt.s))
+		})
+	}
+}
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name      string
+		a         []string
+		b         []string
+		wantOnlyA []string
+		wantOnlyB []string
+		wantBoth  []string
+	}{
+		{
+			name:      "BothEmpty",
+			a:         []string{},
+			b:         []string{},
+			wantOnlyA: []string{},
+			wantOnlyB: []string{},
+			wantBoth:  []string{},
+		},
+		{
+			name:      "Disjoint",
+			a:         []string{"x", "y"},
+			b:         []string{"z"},
+			wantOnlyA: []string{"x", "y"},
+			wantOnlyB: []string{"z"},
+			wantBoth:  []string{},
+		},
+		{
+			name:      "Overlapping",
+			a:         []string{"c", "a", "b", "a"},
+			b:         []string{"d", "b", "c", "e", "d"},
+			wantOnlyA: []string{"a"},
+			wantOnlyB: []string{"d", "e"},
+			wantBoth:  []string{"c", "b"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			onlyA, onlyB, both := Diff(tt.a, tt.b)
+			assert.Equal(t, tt.wantOnlyA, onlyA)
+			assert.Equal(t, tt.wantOnlyB, onlyB)
+			assert.Equal(t, tt.wantBoth, both)
+		})
+	}
+}
+
+func TestEqual(t *testing.T) {
+	tests := []struct {
+		name string
+		a    []int
+		b    []int
+		want bool
+	}{
+		{name: "BothEmpty", a: []int{}, b: nil, want: true},
+		{name: "SameOrder", a: []int{1, 2, 3}, b: []int{1, 2, 3}, want: true},
+		{name: "DifferentOrder", a: []int{1, 2, 3}, b: []int{3, 1, 2}, want: true},
+		{name: "DifferentLength", a: []int{1, 2}, b: []int{1, 2, 3}, want: false},
+		{name: "DifferentElements", a: []int{1, 2, 4}, b: []int{1, 2, 3}, want: false},
+		{name: "DuplicatesSameElements", a: []int{1, 1, 2}, b: []int{2, 1}, want: true},
+		{name: "DuplicatesDifferentElements", a: []int{1, 1}, b: []int{1, 2}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Equal(tt.a, tt.b))
+		})
+	}
+}
+
+func TestHash(t *testing.T) {
+	tests := []struct {
+		name string
+		a    []string
+		b    []string
+		same bool
+	}{
+		{name: "BothEmpty", a: []string{}, b: nil, same: true},
+		{name: "DifferentOrder", a: []string{"x", "y", "z"}, b: []string{"z", "x", "y"}, same: true},
+		{name: "DifferentElements", a: []string{"x", "y"}, b: []string{"x", "z"}, same: false},
+		{name: "Subset", a: []string{"x", "y"}, b: []string{"x"}, same: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.same, Hash(tt.a) == Hash(tt.b))
+		})
+	}
+
+	t.Run("Duplicates", func(t *testing.T) {
+		a, b := []string{"x", "x", "y"}, []string{"x", "y", "y"}
+		assert.True(t, Equal(a, b))
+		assert.Equal(t, Hash(a), Hash(b))
+	})
+
+	t.Run("SameLengthDifferentElements", func(t *testing.T) {
+		a, b := []int{1, 1}, []int{1, 2}
+		assert.False(t, Equal(a, b))
+		assert.NotEqual(t, Hash(a), Hash(b))
+	})
+
+	t.Run("SignedZero", func(t *testing.T) {
+		negZero := math.Copysign(0, -1)
+		a, b := []float64{0, 1}, []float64{negZero, 1}
+		assert.True(t, Equal(a, b))
+		assert.Equal(t, Hash(a), Hash(b))
+	})
+}
+
+func TestUnionAll(t *testing.T) {
+	tests := []struct {
+		name string
+		ss   [][]string
+		want []string
+	}{
+		{name: "NoSlices", ss: nil, want: []string{}},
+		{name: "SingleSlice", ss: [][]string{{"a", "b", "a"}}, want: []string{"a", "b"}},
+		{name: "FirstSeenOrder", ss: [][]string{{"b", "a"}, {"c", "a"}, {}, {"d", "b"}}, want: []string{"b", "a", "c", "d"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, UnionAll(tt.ss...))
+		})
+	}
+}
+
+func TestIntersectionAll(t *testing.T) {
+	tests := []struct {
+		name string
+		ss   [][]string
+		want []string
+	}{
+		{name: "NoSlices", ss: nil, want: []string{}},
+		{name: "SingleSlice", ss: [][]string{{"a", "b", "a"}}, want: []string{"a", "b"}},
+		{name: "OrderFollowsFirst", ss: [][]string{{"c", "a", "b"}, {"a", "b", "c"}, {"b", "c", "a", "a"}}, want: []string{"c", "a", "b"}},
+		{name: "Partial", ss: [][]string{{"read", "write", "admin"}, {"write", "read"}, {"read"}}, want: []string{"read"}},
+		{name: "WithEmpty", ss: [][]string{{"a"}, {}}, want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IntersectionAll(tt.ss...))
+		})
+	}
+}
+
+func TestRemoveIf(t *testing.T) {
+	isEven := func(v int) bool { return v%2 == 0 }
+
+	tests := []struct {
+		name      string
+		s         []int
+		want      []int
+		wantCount int
+	}{
+		{name: "EmptySlice", s: []int{}, want: []int{}, wantCount: 0},
+		{name: "NoneMatch", s: []int{1, 3, 5}, want: []int{1, 3, 5}, wantCount: 0},
+		{name: "SomeMatch", s: []int{1, 2, 3, 4, 5, 6}, want: []int{1, 3, 5}, wantCount: 3},
+		{name: "AllMatch", s: []int{2, 4}, want: []int{}, wantCount: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, count := RemoveIf(tt.s, isEven)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantCount, count)
+		})
+	}
+}
+
+func TestIntersectionSize(t *testing.T) {
+	tests := []struct {
+		name string
+		a    []int
+		b    []int
+		want int
+	}{
+		{name: "BothEmpty", a: []int{}, b: []int{}, want: 0},
+		{name: "OneEmpty", a: []int{1, 2}, b: []int{}, want: 0},
+		{name: "Disjoint", a: []int{1, 2}, b: []int{3, 4}, want: 0},
+		{name: "Overlap", a: []int{1, 2, 3, 4}, b: []int{3, 4, 5}, want: 2},
+		{name: "Subset", a: []int{2}, b: []int{1, 2, 3}, want: 1},
+		{name: "Duplicates", a: []int{1, 1, 1}, b: []int{1, 2, 3, 4}, want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IntersectionSize(tt.a, tt.b))
+			assert.Equal(t, tt.want, IntersectionSize(tt.b, tt.a))
+		})
+	}
+}
+
+func TestChanges(t *testing.T) {
+	tests := []struct {
+		name        string
+		s           []int
+		target      []int
+		wantAdded   []int
+		wantRemoved []int
+	}{
+		{name: "BothEmpty", s: []int{}, target: []int{}, wantAdded: []int{}, wantRemoved: []int{}},
+		{name: "Equal", s: []int{1, 2}, target: []int{2, 1}, wantAdded: []int{}, wantRemoved: []int{}},
+		{name: "FromEmpty", s: []int{}, target: []int{1, 2}, wantAdded: []int{1, 2}, wantRemoved: []int{}},
+		{name: "ToEmpty", s: []int{1, 2}, target: []int{}, wantAdded: []int{}, wantRemoved: []int{1, 2}},
+		{name: "Mixed", s: []int{1, 2, 3}, target: []int{2, 3, 4, 5}, wantAdded: []int{4, 5}, wantRemoved: []int{1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			added, removed := Changes(tt.s, tt.target)
+			assert.Equal(t, tt.wantAdded, added)
+			assert.Equal(t, tt.wantRemoved, removed)
+		})
+	}
+}
+
+func TestAddAll(t *testing.T) {
+	tests := []struct {
+		name      string
+		set       []int
+		other     []int
+		want      []int
+		wantCount int
+	}{
+		{name: "NilOther", set: []int{1, 2}, other: nil, want: []int{1, 2}, wantCount: 0},
+		{name: "IntoEmpty", set: []int{}, other: []int{1, 2}, want: []int{1, 2}, wantCount: 2},
+		{name: "Overlapping", set: []int{1, 2}, other: []int{2, 3, 4}, want: []int{1, 2, 3, 4}, wantCount: 2},
+		{name: "DuplicatesInOther", set: []int{1}, other: []int{2, 2}, want: []int{1, 2}, wantCount: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, count := AddAll(tt.set, tt.other)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantCount, count)
+		})
+	}
+}
+
+func TestRangeSorted(t *testing.T) {
+	less := func(a, b string) bool { return a < b }
+
+	tests := []struct {
+		name  string
+		set   []string
+		limit int
+		want  []string
+	}{
+		{name: "EmptySet", set: []string{}, limit: 10, want: []string{}},
+		{name: "AllElements", set: []string{"c", "a", "b"}, limit: 10, want: []string{"a", "b", "c"}},
+		{name: "StopEarly", set: []string{"c", "a", "d", "b"}, limit: 2, want: []string{"a", "b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orig := append([]string{}, tt.set...)
+
+			got := []string{}
+			RangeSorted(tt.set, less, func(e string) bool {
+				got = append(got, e)
+				return len(got) < tt.limit
+			})
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, orig, tt.set)
+		})
+	}
+}
+
+func TestJaccardSimilarity(t *testing.T) {
+	tests := []struct {
+		name string
+		a    []string
+		b    []string
+		want float64
+	}{
+		{name: "BothEmpty", a: []string{}, b: []string{}, want: 0},
+		{name: "OneEmpty", a: []string{"x"}, b: []string{}, want: 0},
+		{name: "Identical", a: []string{"x", "y"}, b: []string{"y", "x"}, want: 1},
+		{name: "Disjoint", a: []string{"x"}, b: []string{"y"}, want: 0},
+		{name: "Partial", a: []string{"x", "y", "z"}, b: []string{"y", "z", "w"}, want: 0.5},
+		{name: "Duplicates", a: []string{"x", "x"}, b: []string{"x"}, want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.want, JaccardSimilarity(tt.a, tt.b), 1e-9)
+		})
+	}
+}
+
+func TestToSortedSlice(t *testing.T) {
+	type member struct {
+		name string
+		rank int
+	}
+	byRank := func(a, b member) bool { return a.rank < b.rank }
+
+	tests := []struct {
+		name string
+		set  []member
+		want []member
+	}{
+		{name: "EmptySet", set: []member{}, want: []member{}},
+		{name: "Unsorted", set: []member{{"c", 3}, {"a", 1}, {"b", 2}}, want: []member{{"a", 1}, {"b", 2}, {"c", 3}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orig := append([]member{}, tt.set...)
+			assert.Equal(t, tt.want, ToSortedSlice(tt.set, byRank))
+			assert.Equal(t, orig, tt.set)
+		})
+	}
+}
+
+func TestSortedSlice(t *testing.T) {
+	tests := []struct {
+		name string
+		set  []string
+		want []string
+	}{
+		{name: "EmptySet", set: []string{}, want: []string{}},
+		{name: "Unsorted", set: []string{"pear", "apple", "fig"}, want: []string{"apple", "fig", "pear"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orig := append([]string{}, tt.set...)
+			assert.Equal(t, tt.want, SortedSlice(tt.set))
+			assert.Equal(t, orig, tt.set)
+		})
+	}
+}
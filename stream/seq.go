@@ -0,0 +1,23 @@
+//go:build go1.23
+
+package stream
+
+import "iter"
+
+// ChunkSeq returns an iterator over successive sub-slices of s holding at most size elements.
+// The chunks are views into s and are produced lazily, the last one may be shorter than size.
+// A size <= 0 yields nothing.
+func ChunkSeq[E any](s []E, size int) iter.Seq[[]E] {
+	return func(yield func([]E) bool) {
+		if size <= 0 {
+			return
+		}
+
+		for start := 0; start < len(s); start += size {
+			end := min(start+size, len(s))
+			if !yield(s[start:end:end]) {
+				return
+			}
+		}
+	}
+}
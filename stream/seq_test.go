@@ -0,0 +1,62 @@
+//go:build go1.23
+
+package stream
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestChunkSeq(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    []int
+		size     int
+		expected [][]int
+	}{
+		{
+			name:     "empty slice",
+			input:    []int{},
+			size:     2,
+			expected: nil,
+		},
+		{
+			name:     "invalid size",
+			input:    []int{1, 2},
+			size:     0,
+			expected: nil,
+		},
+		{
+			name:     "partial last chunk",
+			input:    []int{1, 2, 3, 4, 5},
+			size:     2,
+			expected: [][]int{{1, 2}, {3, 4}, {5}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var result [][]int
+			for chunk := range ChunkSeq(tc.input, tc.size) {
+				result = append(result, chunk)
+			}
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("ChunkSeq(%v, %d) = %v; expected %v", tc.input, tc.size, result, tc.expected)
+			}
+		})
+	}
+
+	t.Run("early break", func(t *testing.T) {
+		var result [][]int
+		for chunk := range ChunkSeq([]int{1, 2, 3, 4, 5}, 2) {
+			result = append(result, chunk)
+			if len(result) == 2 {
+				break
+			}
+		}
+		expected := [][]int{{1, 2}, {3, 4}}
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("ChunkSeq() with break = %v; expected %v", result, expected)
+		}
+	})
+}
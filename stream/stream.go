@@ -1,8 +1,22 @@
 package stream
 
 import (
+	"container/heap"
+	"context"
+	"errors"
+	"fmt"
+	"math"
 	"math/rand"
+	"reflect"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
+
+	"golang.org/x/exp/constraints"
+	"golang.org/x/exp/slices"
 )
 
 func Limit[E any](s []E, n int) []E {
@@ -164,3 +178,977 @@ func GroupBy[E any, K comparable](s []E, getKey func(E) K) map[K][]E {
 
 	return result
 }
+
+// Batch calls f with successive sub-slices of s holding at most size elements.
+// The batches are views into s, the last one may be shorter than size.
+// It stops and returns the first error returned by f.
+func Batch[E any](s []E, size int, f func(batch []E) error) error {
+	if size <= 0 {
+		return errors.New("batch size must be positive")
+	}
+
+	for start := 0; start < len(s); start += size {
+		end := start + size
+		if end > len(s) {
+			end = len(s)
+		}
+
+		if err := f(s[start:end:end]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func SortedDistinct[E constraints.Ordered](s []E) []E {
+	ret := make([]E, len(s))
+	copy(ret, s)
+
+	slices.Sort(ret)
+
+	return slices.Compact(ret)
+}
+
+func DropNil[E any](s []*E) []*E {
+	ret := make([]*E, 0, len(s))
+	for _, v := range s {
+		if v != nil {
+			ret = append(ret, v)
+		}
+	}
+	return ret
+}
+
+func ZipWith[A, B, C any](a []A, b []B, f func(A, B) C) []C {
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+
+	ret := make([]C, 0, n)
+	for i := 0; i < n; i++ {
+		ret = append(ret, f(a[i], b[i]))
+	}
+	return ret
+}
+
+func Zip3[A, B, C, D any](a []A, b []B, c []C, f func(A, B, C) D) []D {
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+	if len(c) < n {
+		n = len(c)
+	}
+
+	ret := make([]D, 0, n)
+	for i := 0; i < n; i++ {
+		ret = append(ret, f(a[i], b[i], c[i]))
+	}
+	return ret
+}
+
+// PartitionN routes each element into one of n buckets by the index returned from bucket.
+// Indices below 0 are clamped to 0 and indices above n-1 are clamped to n-1.
+func PartitionN[E any](s []E, n int, bucket func(E) int) [][]E {
+	if n <= 0 {
+		return [][]E{}
+	}
+
+	ret := make([][]E, n)
+	for i := range ret {
+		ret[i] = []E{}
+	}
+
+	for _, v := range s {
+		i := bucket(v)
+		if i < 0 {
+			i = 0
+		} else if i >= n {
+			i = n - 1
+		}
+		ret[i] = append(ret[i], v)
+	}
+
+	return ret
+}
+
+func ReduceWhile[E, A any](s []E, init A, acc func(A, E) (A, bool)) A {
+	ret := init
+	for _, v := range s {
+		var ok bool
+		if ret, ok = acc(ret, v); !ok {
+			break
+		}
+	}
+	return ret
+}
+
+func JoinToString[E any](s []E, sep string, f func(E) string) string {
+	var sb strings.Builder
+	for i, v := range s {
+		if i > 0 {
+			sb.WriteString(sep)
+		}
+		sb.WriteString(f(v))
+	}
+	return sb.String()
+}
+
+func Flatten[E any](s [][]E) []E {
+	n := 0
+	for _, inner := range s {
+		n += len(inner)
+	}
+
+	ret := make([]E, 0, n)
+	for _, inner := range s {
+		ret = append(ret, inner...)
+	}
+	return ret
+}
+
+// Merge merges slices that are each already sorted by less into one sorted slice.
+// Elements that compare equal keep the order of the slices they come from.
+func Merge[E any](less func(a, b E) bool, ss ...[]E) []E {
+	h := &mergeHeap[E]{less: less}
+	n := 0
+	for i, s := range ss {
+		if len(s) > 0 {
+			h.cursors = append(h.cursors, mergeCursor{slice: i})
+			n += len(s)
+		}
+	}
+	h.slices = ss
+	heap.Init(h)
+
+	ret := make([]E, 0, n)
+	for h.Len() > 0 {
+		c := &h.cursors[0]
+		ret = append(ret, ss[c.slice][c.pos])
+
+		c.pos++
+		if c.pos < len(ss[c.slice]) {
+			heap.Fix(h, 0)
+		} else {
+			heap.Pop(h)
+		}
+	}
+	return ret
+}
+
+type mergeCursor struct {
+	slice int
+	pos   int
+}
+
+type mergeHeap[E any] struct {
+	slices  [][]E
+	cursors []mergeCursor
+	less    func(a, b E) bool
+}
+
+func (h *mergeHeap[E]) Len() int {
+	return len(h.cursors)
+}
+
+func (h *mergeHeap[E]) Less(i, j int) bool {
+	ci, cj := h.cursors[i], h.cursors[j]
+	a, b := h.slices[ci.slice][ci.pos], h.slices[cj.slice][cj.pos]
+	if h.less(a, b) {
+		return true
+	}
+	if h.less(b, a) {
+		return false
+	}
+	return ci.slice < cj.slice
+}
+
+func (h *mergeHeap[E]) Swap(i, j int) {
+	h.cursors[i], h.cursors[j] = h.cursors[j], h.cursors[i]
+}
+
+func (h *mergeHeap[E]) Push(x any) {
+	h.cursors = append(h.cursors, x.(mergeCursor))
+}
+
+func (h *mergeHeap[E]) Pop() any {
+	old := h.cursors
+	x := old[len(old)-1]
+	h.cursors = old[:len(old)-1]
+	return x
+}
+
+type Group[K comparable, E any] struct {
+	Key   K
+	Items []E
+}
+
+func GroupConsecutive[E any, K comparable](s []E, key func(E) K) []Group[K, E] {
+	ret := make([]Group[K, E], 0)
+
+	for _, v := range s {
+		k := key(v)
+		if n := len(ret); n > 0 && ret[n-1].Key == k {
+			ret[n-1].Items = append(ret[n-1].Items, v)
+		} else {
+			ret = append(ret, Group[K, E]{Key: k, Items: []E{v}})
+		}
+	}
+
+	return ret
+}
+
+func MapKeys[K comparable, V any](m map[K]V) []K {
+	ret := make([]K, 0, len(m))
+	for k := range m {
+		ret = append(ret, k)
+	}
+	return ret
+}
+
+func MapValues[K comparable, V any](m map[K]V) []V {
+	ret := make([]V, 0, len(m))
+	for _, v := range m {
+		ret = append(ret, v)
+	}
+	return ret
+}
+
+func TryReduce[E, A any](s []E, init A, acc func(A, E) (A, error)) (A, error) {
+	ret := init
+	for _, v := range s {
+		var err error
+		if ret, err = acc(ret, v); err != nil {
+			return ret, err
+		}
+	}
+	return ret, nil
+}
+
+// UniqueByLast keeps the last element for each key, ordered by where those last occurrences appear in s.
+// Unlike Distinct and DistinctFunc, which keep the first occurrence, later elements supersede earlier ones.
+func UniqueByLast[E any, K comparable](s []E, key func(E) K) []E {
+	last := make(map[K]int, len(s))
+	for i, v := range s {
+		last[key(v)] = i
+	}
+
+	ret := make([]E, 0, len(last))
+	for i, v := range s {
+		if last[key(v)] == i {
+			ret = append(ret, v)
+		}
+	}
+	return ret
+}
+
+func Coalesce[E comparable](values ...E) E {
+	var zero E
+	for _, v := range values {
+		if v != zero {
+			return v
+		}
+	}
+	return zero
+}
+
+func FilterIndexed[E any](s []E, pred func(i int, e E) bool) []E {
+	ret := make([]E, 0, len(s))
+	for i, v := range s {
+		if pred(i, v) {
+			ret = append(ret, v)
+		}
+	}
+	return ret
+}
+
+func SortedStableFunc[E any](s []E, less func(a, b E) bool) []E {
+	ret := make([]E, len(s))
+	copy(ret, s)
+
+	sort.SliceStable(ret, func(i, j int) bool {
+		return less(ret[i], ret[j])
+	})
+
+	return ret
+}
+
+// ToChannel sends the elements of s on the returned channel from a new goroutine and closes it
+// when done. Cancelling ctx stops the goroutine early and closes the channel, so a consumer that
+// stops reading before the end must cancel ctx to avoid leaking it.
+func ToChannel[E any](ctx context.Context, s []E, buffer int) <-chan E {
+	if buffer < 0 {
+		buffer = 0
+	}
+
+	ch := make(chan E, buffer)
+	go func() {
+		defer close(ch)
+		for _, v := range s {
+			select {
+			case ch <- v:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+func FromChannel[E any](ch <-chan E) []E {
+	ret := make([]E, 0)
+	for v := range ch {
+		ret = append(ret, v)
+	}
+	return ret
+}
+
+func MinMax[E constraints.Ordered](s []E) (min, max E, ok bool) {
+	if len(s) == 0 {
+		return
+	}
+
+	min, max = s[0], s[0]
+
+	// compare elements pairwise: ~3 comparisons per 2 elements instead of 4
+	i := 1
+	if len(s)%2 == 0 {
+		if s[1] < min {
+			min = s[1]
+		} else {
+			max = s[1]
+		}
+		i = 2
+	}
+
+	for ; i+1 < len(s); i += 2 {
+		lo, hi := s[i], s[i+1]
+		if hi < lo {
+			lo, hi = hi, lo
+		}
+		if lo < min {
+			min = lo
+		}
+		if hi > max {
+			max = hi
+		}
+	}
+
+	return min, max, true
+}
+
+func Apply[E any](s []E, transforms ...func([]E) []E) []E {
+	ret := make([]E, len(s))
+	copy(ret, s)
+
+	for _, transform := range transforms {
+		ret = transform(ret)
+	}
+	return ret
+}
+
+func DedupFunc[E any](s []E, eq func(a, b E) bool) []E {
+	ret := make([]E, 0, len(s))
+	for _, v := range s {
+		if len(ret) == 0 || !eq(ret[len(ret)-1], v) {
+			ret = append(ret, v)
+		}
+	}
+	return ret
+}
+
+// Product returns the product of all elements, or 1 for an empty slice.
+// Integer products overflow and wrap around like regular Go multiplication.
+func Product[E Number](s []E) E {
+	var ret E = 1
+	for _, v := range s {
+		ret *= v
+	}
+	return ret
+}
+
+func AtLeast[E any](s []E, n int, pred func(E) bool) bool {
+	if n <= 0 {
+		return true
+	}
+
+	count := 0
+	for _, v := range s {
+		if pred(v) {
+			count++
+			if count >= n {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func AtMost[E any](s []E, n int, pred func(E) bool) bool {
+	if n < 0 {
+		return false
+	}
+
+	count := 0
+	for _, v := range s {
+		if pred(v) {
+			count++
+			if count > n {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// ChunkEvenly splits s into exactly parts sub-slices whose sizes differ by at most one,
+// with the earlier chunks taking the remainder. When parts exceeds len(s) the trailing
+// chunks are empty. A parts value <= 0 returns an empty result.
+func ChunkEvenly[E any](s []E, parts int) [][]E {
+	if parts <= 0 {
+		return [][]E{}
+	}
+
+	ret := make([][]E, 0, parts)
+	size, rem := len(s)/parts, len(s)%parts
+
+	start := 0
+	for i := 0; i < parts; i++ {
+		end := start + size
+		if i < rem {
+			end++
+		}
+		ret = append(ret, s[start:end:end])
+		start = end
+	}
+	return ret
+}
+
+func ReduceRight[E, A any](s []E, init A, acc func(E, A) A) A {
+	ret := init
+	for i := len(s) - 1; i >= 0; i-- {
+		ret = acc(s[i], ret)
+	}
+	return ret
+}
+
+func ToMapMerge[E any, K comparable, V any](s []E, kv func(E) (K, V), merge func(existing, new V) V) map[K]V {
+	result := make(map[K]V)
+
+	for _, e := range s {
+		k, v := kv(e)
+		if existing, ok := result[k]; ok {
+			result[k] = merge(existing, v)
+		} else {
+			result[k] = v
+		}
+	}
+
+	return result
+}
+
+func Span[E any](s []E, pred func(E) bool) (prefix, rest []E) {
+	if s == nil {
+		s = []E{}
+	}
+
+	i := 0
+	for i < len(s) && pred(s[i]) {
+		i++
+	}
+	return s[:i:i], s[i:]
+}
+
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+func Enumerate[E any](s []E) []Pair[int, E] {
+	ret := make([]Pair[int, E], 0, len(s))
+	for i, v := range s {
+		ret = append(ret, Pair[int, E]{First: i, Second: v})
+	}
+	return ret
+}
+
+// Histogram counts the elements per bucket, where an element falls into bucket floor(value/bucketSize).
+// It panics if bucketSize is not positive, or if a value is NaN, infinite or lands in a bucket
+// outside the int range.
+func Histogram[E Number](s []E, bucketSize float64) map[int]int {
+	if !(bucketSize > 0) {
+		panic(errors.New("bucket size must be positive"))
+	}
+
+	result := make(map[int]int)
+	for _, v := range s {
+		bucket := math.Floor(float64(v) / bucketSize)
+		if math.IsNaN(bucket) || bucket < math.MinInt || bucket >= -math.MinInt {
+			panic(fmt.Errorf("value %v has no histogram bucket for bucket size %v", v, bucketSize))
+		}
+		result[int(bucket)]++
+	}
+	return result
+}
+
+// GroupByComposite groups elements by a multi-part key.
+// Each part is encoded as "<len>:<type>:<value>" and the parts are concatenated, so the length
+// prefix keeps part boundaries unambiguous and the type keeps equal-looking values of different
+// types apart. Pointers, channels and funcs are encoded by address (%p), everything else by its
+// Go-syntax value (%#v). Values with a GoString method are encoded by that method, so such a type
+// must return distinct strings for keys that should not share a group.
+func GroupByComposite[E any](s []E, key func(E) []any) map[string][]E {
+	result := make(map[string][]E)
+
+	for _, v := range s {
+		k := compositeKey(key(v))
+		result[k] = append(result[k], v)
+	}
+
+	return result
+}
+
+func compositeKey(parts []any) string {
+	var sb strings.Builder
+	for _, part := range parts {
+		var p string
+		switch reflect.ValueOf(part).Kind() {
+		case reflect.Pointer, reflect.Chan, reflect.Func, reflect.UnsafePointer:
+			p = fmt.Sprintf("%T:%p", part, part)
+		default:
+			p = fmt.Sprintf("%T:%#v", part, part)
+		}
+		sb.WriteString(strconv.Itoa(len(p)))
+		sb.WriteByte(':')
+		sb.WriteString(p)
+	}
+	return sb.String()
+}
+
+// ForEachBatchParallel splits s into batches of batchSize and calls f for them on up to workers goroutines.
+// It returns the first error returned by f, after which no further batches are started.
+// A batchSize <= 0 processes s as a single batch, and a workers value <= 0 defaults to GOMAXPROCS.
+func ForEachBatchParallel[E any](s []E, batchSize, workers int, f func(batch []E) error) error {
+	if batchSize <= 0 {
+		batchSize = len(s)
+	}
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	batches := make(chan []E)
+	var wg sync.WaitGroup
+	var once sync.Once
+	var firstErr error
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for batch := range batches {
+				if ctx.Err() != nil {
+					continue
+				}
+				if err := f(batch); err != nil {
+					once.Do(func() {
+						firstErr = err
+						cancel()
+					})
+				}
+			}
+		}()
+	}
+
+	_ = Batch(s, batchSize, func(batch []E) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		select {
+		case batches <- batch:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+	close(batches)
+
+	wg.Wait()
+	return firstErr
+}
+
+func UniqueWithSeen[E comparable](s []E) ([]E, map[E]struct{}) {
+	seen := make(map[E]struct{})
+	ret := make([]E, 0, len(s))
+	for _, v := range s {
+		if _, ok := seen[v]; !ok {
+			ret = append(ret, v)
+			seen[v] = struct{}{}
+		}
+	}
+	return ret, seen
+}
+
+// SortedByKey returns a copy of s sorted by key, calling key exactly once per element.
+func SortedByKey[E any, K constraints.Ordered](s []E, key func(E) K) []E {
+	decorated := make([]Pair[K, E], 0, len(s))
+	for _, v := range s {
+		decorated = append(decorated, Pair[K, E]{First: key(v), Second: v})
+	}
+
+	sort.SliceStable(decorated, func(i, j int) bool {
+		return decorated[i].First < decorated[j].First
+	})
+
+	ret := make([]E, 0, len(s))
+	for _, p := range decorated {
+		ret = append(ret, p.Second)
+	}
+	return ret
+}
+
+// FlattenDeep flattens the elements of the slice v, descending into nested slices up to depth
+// levels, or all the way down when depth < 0. Non-slice values are emitted as-is, and a
+// non-slice v yields a single element.
+// Nesting is inspected with reflection, which is considerably slower than Flatten.
+func FlattenDeep(v any, depth int) []any {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() || rv.Kind() != reflect.Slice {
+		return []any{v}
+	}
+
+	ret := make([]any, 0, rv.Len())
+	return flattenDeep(ret, rv, depth)
+}
+
+func flattenDeep(ret []any, rv reflect.Value, depth int) []any {
+	for i := 0; i < rv.Len(); i++ {
+		elem := rv.Index(i)
+		if elem.Kind() == reflect.Interface {
+			elem = elem.Elem()
+		}
+
+		if depth != 0 && elem.IsValid() && elem.Kind() == reflect.Slice {
+			ret = flattenDeep(ret, elem, depth-1)
+		} else if elem.IsValid() {
+			ret = append(ret, elem.Interface())
+		} else {
+			ret = append(ret, nil)
+		}
+	}
+	return ret
+}
+
+// ReduceWindows calls reduce for every full window of size elements, starting a new window
+// every step elements, and returns the reduced values. The windows passed to reduce are views into s.
+// A size or step <= 0, or a size larger than len(s), returns an empty result.
+func ReduceWindows[E, A any](s []E, size, step int, reduce func([]E) A) []A {
+	ret := make([]A, 0)
+	if size <= 0 || step <= 0 {
+		return ret
+	}
+
+	for start := 0; start+size <= len(s); start += step {
+		end := start + size
+		ret = append(ret, reduce(s[start:end:end]))
+	}
+	return ret
+}
+
+func DedupBy[E any, K comparable](s []E, key func(E) K) []E {
+	ret := make([]E, 0, len(s))
+
+	var last K
+	for i, v := range s {
+		k := key(v)
+		if i == 0 || k != last {
+			ret = append(ret, v)
+			last = k
+		}
+	}
+	return ret
+}
+
+func PadRight[E any](s []E, length int, fill E) []E {
+	if length < len(s) {
+		length = len(s)
+	}
+
+	ret := make([]E, 0, length)
+	ret = append(ret, s...)
+	for len(ret) < length {
+		ret = append(ret, fill)
+	}
+	return ret
+}
+
+func PadLeft[E any](s []E, length int, fill E) []E {
+	if length < len(s) {
+		length = len(s)
+	}
+
+	ret := make([]E, 0, length)
+	for i := len(s); i < length; i++ {
+		ret = append(ret, fill)
+	}
+	return append(ret, s...)
+}
+
+// MapCollectErrors applies mapFunc to every element and, unlike Map, does not stop at the first error.
+// It returns the successful results in order, and each error wrapped with the index of its element.
+func MapCollectErrors[E1, E2 any](s1 []E1, mapFunc func(E1) (E2, error)) (s2 []E2, errs []error) {
+	s2 = make([]E2, 0, len(s1))
+	for i, e1 := range s1 {
+		e2, err := mapFunc(e1)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("index %d: %w", i, err))
+			continue
+		}
+		s2 = append(s2, e2)
+	}
+	return s2, errs
+}
+
+// Transpose turns an m×n grid into an n×m one. Ragged rows are padded with zero values
+// up to the length of the longest row.
+func Transpose[E any](rows [][]E) [][]E {
+	width := 0
+	for _, row := range rows {
+		if len(row) > width {
+			width = len(row)
+		}
+	}
+
+	ret := make([][]E, width)
+	for j := range ret {
+		ret[j] = make([]E, len(rows))
+		for i, row := range rows {
+			if j < len(row) {
+				ret[j][i] = row[j]
+			}
+		}
+	}
+	return ret
+}
+
+func Min[E constraints.Ordered](s []E) (E, bool) {
+	if len(s) == 0 {
+		var zero E
+		return zero, false
+	}
+
+	ret := s[0]
+	for _, v := range s[1:] {
+		if v < ret {
+			ret = v
+		}
+	}
+	return ret, true
+}
+
+func Max[E constraints.Ordered](s []E) (E, bool) {
+	if len(s) == 0 {
+		var zero E
+		return zero, false
+	}
+
+	ret := s[0]
+	for _, v := range s[1:] {
+		if v > ret {
+			ret = v
+		}
+	}
+	return ret, true
+}
+
+func FindIndexed[E any](s []E, pred func(E) bool) (i int, e E, found bool) {
+	for i, e = range s {
+		if pred(e) {
+			return i, e, true
+		}
+	}
+
+	var zero E
+	return -1, zero, false
+}
+
+// MinFunc returns the smallest element according to less; on ties the first one encountered wins.
+func MinFunc[E any](s []E, less func(a, b E) bool) (E, bool) {
+	if len(s) == 0 {
+		var zero E
+		return zero, false
+	}
+
+	ret := s[0]
+	for _, v := range s[1:] {
+		if less(v, ret) {
+			ret = v
+		}
+	}
+	return ret, true
+}
+
+// MaxFunc returns the largest element according to less; on ties the first one encountered wins.
+func MaxFunc[E any](s []E, less func(a, b E) bool) (E, bool) {
+	if len(s) == 0 {
+		var zero E
+		return zero, false
+	}
+
+	ret := s[0]
+	for _, v := range s[1:] {
+		if less(ret, v) {
+			ret = v
+		}
+	}
+	return ret, true
+}
+
+func Sort[E constraints.Ordered](s []E) []E {
+	ret := make([]E, len(s))
+	copy(ret, s)
+
+	slices.Sort(ret)
+
+	return ret
+}
+
+func SortFunc[E any](s []E, less func(a, b E) bool) []E {
+	ret := make([]E, len(s))
+	copy(ret, s)
+
+	sort.Slice(ret, func(i, j int) bool {
+		return less(ret[i], ret[j])
+	})
+
+	return ret
+}
+
+// Cycle repeats the elements of s in order until the result holds length elements.
+// An empty s or a length <= 0 returns an empty result.
+func Cycle[E any](s []E, length int) []E {
+	if len(s) == 0 || length <= 0 {
+		return []E{}
+	}
+
+	ret := make([]E, 0, length)
+	for len(ret) < length {
+		ret = append(ret, Limit(s, length-len(ret))...)
+	}
+	return ret
+}
+
+func Reverse[E any](s []E) (ret []E) {
+	if len(s) == 0 {
+		return
+	}
+
+	ret = make([]E, len(s))
+	for i, v := range s {
+		ret[len(s)-1-i] = v
+	}
+	return ret
+}
+
+// Chunk splits s into sub-slices of size elements, the last one may be shorter.
+// The chunks are views into s. A size <= 0 returns an empty result.
+func Chunk[E any](s []E, size int) [][]E {
+	if size <= 0 {
+		return [][]E{}
+	}
+
+	ret := make([][]E, 0, (len(s)+size-1)/size)
+	_ = Batch(s, size, func(batch []E) error {
+		ret = append(ret, batch)
+		return nil
+	})
+	return ret
+}
+
+func MapGroups[K comparable, E any, R any](groups map[K][]E, f func(K, []E) R) map[K]R {
+	result := make(map[K]R, len(groups))
+
+	for k, items := range groups {
+		result[k] = f(k, items)
+	}
+
+	return result
+}
+
+func Partition[E any](s []E, pred func(E) bool) (matched, unmatched []E) {
+	matched, unmatched = []E{}, []E{}
+	for _, v := range s {
+		if pred(v) {
+			matched = append(matched, v)
+		} else {
+			unmatched = append(unmatched, v)
+		}
+	}
+	return
+}
+
+// Number is a constraint that permits any integer or floating-point type.
+type Number interface {
+	constraints.Integer | constraints.Float
+}
+
+func Sum[E Number](s []E) E {
+	var ret E
+	for _, v := range s {
+		ret += v
+	}
+	return ret
+}
+
+// Average returns the arithmetic mean of s, or false for an empty slice.
+func Average[E Number](s []E) (float64, bool) {
+	if len(s) == 0 {
+		return 0, false
+	}
+
+	var sum float64
+	for _, v := range s {
+		sum += float64(v)
+	}
+	return sum / float64(len(s)), true
+}
+
+func SplitByBool[E any](s []E, pred func(E) bool) (runs [][]E) {
+	runs = [][]E{}
+	if len(s) == 0 {
+		return
+	}
+
+	start, current := 0, pred(s[0])
+	for i := 1; i < len(s); i++ {
+		if p := pred(s[i]); p != current {
+			runs = append(runs, s[start:i:i])
+			start, current = i, p
+		}
+	}
+	return append(runs, s[start:])
+}
+
+func SumBy[E any, K comparable](s []E, keyFunc func(E) K, valFunc func(E) float64) map[K]float64 {
+	result := make(map[K]float64)
+
+	for _, v := range s {
+		result[keyFunc(v)] += valFunc(v)
+	}
+
+	return result
+}
+
+func CountBy[E any, K comparable](s []E, keyFunc func(E) K) map[K]int {
+	result := make(map[K]int)
+
+	for _, v := range s {
+		result[keyFunc(v)]++
+	}
+
+	return result
+}
@@ -1,8 +1,14 @@
 package stream
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"math"
 	"reflect"
+	"sort"
+	"strconv"
+	"sync"
 	"testing"
 )
 
@@ -727,3 +733,2407 @@ func TestDistinctFunc(t *testing.T) {
 		})
 	}
 }
+
+func TestBatch(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    []int
+		size     int
+		expected [][]int
+	}{
+		{
+			name:     "empty slice",
+			input:    []int{},
+			size:     2,
+			expected: nil,
+		},
+		{
+			name:     "exact batches",
+			input:    []int{1, 2, 3, 4},
+			size:     2,
+			expected: [][]int{{1, 2}, {3, 4}},
+		},
+		{
+			name:     "partial last batch",
+			input:    []int{1, 2, 3, 4, 5},
+			size:     2,
+			expected: [][]int{{1, 2}, {3, 4}, {5}},
+		},
+		{
+			name:     "size larger than slice",
+			input:    []int{1, 2, 3},
+			size:     10,
+			expected: [][]int{{1, 2, 3}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var result [][]int
+			err := Batch(tc.input, tc.size, func(batch []int) error {
+				result = append(result, batch)
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("Batch(%v, %d) returned error: %v", tc.input, tc.size, err)
+			}
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("Batch(%v, %d) = %v; expected %v", tc.input, tc.size, result, tc.expected)
+			}
+		})
+	}
+
+	t.Run("error aborts", func(t *testing.T) {
+		wantErr := errors.New("stop")
+		calls := 0
+		err := Batch([]int{1, 2, 3, 4, 5}, 2, func(batch []int) error {
+			calls++
+			if calls == 2 {
+				return wantErr
+			}
+			return nil
+		})
+		if err != wantErr {
+			t.Errorf("Batch() error = %v; expected %v", err, wantErr)
+		}
+		if calls != 2 {
+			t.Errorf("Batch() called f %d times; expected 2", calls)
+		}
+	})
+
+	t.Run("invalid size", func(t *testing.T) {
+		if err := Batch([]int{1}, 0, func([]int) error { return nil }); err == nil {
+			t.Error("Batch() with size 0 expected error")
+		}
+	})
+}
+
+func TestSortedDistinct(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    []int
+		expected []int
+	}{
+		{
+			name:     "empty slice",
+			input:    []int{},
+			expected: []int{},
+		},
+		{
+			name:     "already sorted unique",
+			input:    []int{1, 2, 3},
+			expected: []int{1, 2, 3},
+		},
+		{
+			name:     "unsorted with duplicates",
+			input:    []int{3, 1, 2, 3, 1, 5, 2},
+			expected: []int{1, 2, 3, 5},
+		},
+		{
+			name:     "all equal",
+			input:    []int{7, 7, 7},
+			expected: []int{7},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := make([]int, len(tc.input))
+			copy(input, tc.input)
+			result := SortedDistinct(tc.input)
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("SortedDistinct(%v) = %v; expected %v", tc.input, result, tc.expected)
+			}
+			if !reflect.DeepEqual(tc.input, input) {
+				t.Errorf("SortedDistinct modified its input: %v", tc.input)
+			}
+		})
+	}
+}
+
+func TestDropNil(t *testing.T) {
+	one, two, three := 1, 2, 3
+
+	testCases := []struct {
+		name     string
+		input    []*int
+		expected []*int
+	}{
+		{
+			name:     "empty slice",
+			input:    []*int{},
+			expected: []*int{},
+		},
+		{
+			name:     "no nils",
+			input:    []*int{&one, &two},
+			expected: []*int{&one, &two},
+		},
+		{
+			name:     "mixed",
+			input:    []*int{nil, &one, nil, &two, &three, nil},
+			expected: []*int{&one, &two, &three},
+		},
+		{
+			name:     "all nil",
+			input:    []*int{nil, nil},
+			expected: []*int{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := DropNil(tc.input)
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("DropNil(%v) = %v; expected %v", tc.input, result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestZipWith(t *testing.T) {
+	type point struct {
+		x, y int
+	}
+
+	testCases := []struct {
+		name     string
+		xs       []int
+		ys       []int
+		expected []point
+	}{
+		{
+			name:     "empty input",
+			xs:       []int{},
+			ys:       []int{1, 2},
+			expected: []point{},
+		},
+		{
+			name:     "equal length",
+			xs:       []int{1, 2},
+			ys:       []int{3, 4},
+			expected: []point{{1, 3}, {2, 4}},
+		},
+		{
+			name:     "stops at shortest",
+			xs:       []int{1, 2, 3},
+			ys:       []int{4},
+			expected: []point{{1, 4}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := ZipWith(tc.xs, tc.ys, func(x, y int) point { return point{x, y} })
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("ZipWith(%v, %v) = %v; expected %v", tc.xs, tc.ys, result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestZip3(t *testing.T) {
+	testCases := []struct {
+		name     string
+		a        []int
+		b        []string
+		c        []bool
+		expected []string
+	}{
+		{
+			name:     "empty input",
+			a:        []int{1},
+			b:        []string{},
+			c:        []bool{true},
+			expected: []string{},
+		},
+		{
+			name:     "stops at shortest",
+			a:        []int{1, 2, 3},
+			b:        []string{"a", "b", "c"},
+			c:        []bool{true, false},
+			expected: []string{"1a:true", "2b:false"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := Zip3(tc.a, tc.b, tc.c, func(a int, b string, c bool) string {
+				return fmt.Sprintf("%d%s:%t", a, b, c)
+			})
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("Zip3() = %v; expected %v", result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestPartitionN(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    []int
+		n        int
+		bucket   func(int) int
+		expected [][]int
+	}{
+		{
+			name:     "empty slice",
+			input:    []int{},
+			n:        3,
+			bucket:   func(v int) int { return v % 3 },
+			expected: [][]int{{}, {}, {}},
+		},
+		{
+			name:     "modulo buckets",
+			input:    []int{1, 2, 3, 4, 5, 6, 7},
+			n:        3,
+			bucket:   func(v int) int { return v % 3 },
+			expected: [][]int{{3, 6}, {1, 4, 7}, {2, 5}},
+		},
+		{
+			name:     "out of range indices are clamped",
+			input:    []int{-5, 0, 5, 10},
+			n:        2,
+			bucket:   func(v int) int { return v },
+			expected: [][]int{{-5, 0}, {5, 10}},
+		},
+		{
+			name:     "no buckets",
+			input:    []int{1, 2},
+			n:        0,
+			bucket:   func(v int) int { return v },
+			expected: [][]int{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := PartitionN(tc.input, tc.n, tc.bucket)
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("PartitionN(%v, %d) = %v; expected %v", tc.input, tc.n, result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestReduceWhile(t *testing.T) {
+	sumUntil := func(limit int) func(int, int) (int, bool) {
+		return func(acc, v int) (int, bool) {
+			acc += v
+			return acc, acc < limit
+		}
+	}
+
+	testCases := []struct {
+		name     string
+		input    []int
+		init     int
+		limit    int
+		expected int
+	}{
+		{
+			name:     "empty slice",
+			input:    []int{},
+			init:     5,
+			limit:    10,
+			expected: 5,
+		},
+		{
+			name:     "full pass",
+			input:    []int{1, 2, 3},
+			limit:    100,
+			expected: 6,
+		},
+		{
+			name:     "stops when budget exceeded",
+			input:    []int{4, 4, 4, 4},
+			limit:    10,
+			expected: 12,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := ReduceWhile(tc.input, tc.init, sumUntil(tc.limit))
+			if result != tc.expected {
+				t.Errorf("ReduceWhile(%v) = %v; expected %v", tc.input, result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestJoinToString(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    []int
+		sep      string
+		expected string
+	}{
+		{
+			name:     "empty slice",
+			input:    []int{},
+			sep:      ",",
+			expected: "",
+		},
+		{
+			name:     "single element",
+			input:    []int{1},
+			sep:      ",",
+			expected: "1",
+		},
+		{
+			name:     "multiple elements",
+			input:    []int{1, 2, 3},
+			sep:      ", ",
+			expected: "1, 2, 3",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := JoinToString(tc.input, tc.sep, func(v int) string { return fmt.Sprint(v) })
+			if result != tc.expected {
+				t.Errorf("JoinToString(%v, %q) = %q; expected %q", tc.input, tc.sep, result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    [][]int
+		expected []int
+	}{
+		{
+			name:     "empty slice",
+			input:    [][]int{},
+			expected: []int{},
+		},
+		{
+			name:     "empty inner slices",
+			input:    [][]int{{}, nil, {}},
+			expected: []int{},
+		},
+		{
+			name:     "mixed inner slices",
+			input:    [][]int{{1, 2}, {}, {3}, {4, 5, 6}},
+			expected: []int{1, 2, 3, 4, 5, 6},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := Flatten(tc.input)
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("Flatten(%v) = %v; expected %v", tc.input, result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestMerge(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+
+	testCases := []struct {
+		name     string
+		input    [][]int
+		expected []int
+	}{
+		{
+			name:     "no slices",
+			input:    nil,
+			expected: []int{},
+		},
+		{
+			name:     "empty slices are skipped",
+			input:    [][]int{{}, {1, 3}, nil},
+			expected: []int{1, 3},
+		},
+		{
+			name:     "k-way merge",
+			input:    [][]int{{1, 4, 7}, {2, 5, 8}, {0, 3, 6, 9}},
+			expected: []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+		},
+		{
+			name:     "duplicates",
+			input:    [][]int{{1, 1, 2}, {1, 2, 2}},
+			expected: []int{1, 1, 1, 2, 2, 2},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := Merge(less, tc.input...)
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("Merge(%v) = %v; expected %v", tc.input, result, tc.expected)
+			}
+		})
+	}
+
+	t.Run("equal elements keep slice order", func(t *testing.T) {
+		type item struct {
+			key int
+			src string
+		}
+		byKey := func(a, b item) bool { return a.key < b.key }
+
+		result := Merge(byKey,
+			[]item{{1, "a"}, {2, "a"}},
+			[]item{{1, "b"}, {2, "b"}},
+		)
+		expected := []item{{1, "a"}, {1, "b"}, {2, "a"}, {2, "b"}}
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Merge() = %v; expected %v", result, expected)
+		}
+	})
+}
+
+func TestGroupConsecutive(t *testing.T) {
+	type line struct {
+		level string
+		msg   string
+	}
+	byLevel := func(l line) string { return l.level }
+
+	testCases := []struct {
+		name     string
+		input    []line
+		expected []Group[string, line]
+	}{
+		{
+			name:     "empty slice",
+			input:    []line{},
+			expected: []Group[string, line]{},
+		},
+		{
+			name:  "recurring key forms separate groups",
+			input: []line{{"info", "a"}, {"info", "b"}, {"warn", "c"}, {"info", "d"}},
+			expected: []Group[string, line]{
+				{Key: "info", Items: []line{{"info", "a"}, {"info", "b"}}},
+				{Key: "warn", Items: []line{{"warn", "c"}}},
+				{Key: "info", Items: []line{{"info", "d"}}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := GroupConsecutive(tc.input, byLevel)
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("GroupConsecutive(%v) = %v; expected %v", tc.input, result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestMapKeysAndValues(t *testing.T) {
+	testCases := []struct {
+		name           string
+		input          map[string]int
+		expectedKeys   []string
+		expectedValues []int
+	}{
+		{
+			name:           "empty map",
+			input:          map[string]int{},
+			expectedKeys:   []string{},
+			expectedValues: []int{},
+		},
+		{
+			name:           "nil map",
+			input:          nil,
+			expectedKeys:   []string{},
+			expectedValues: []int{},
+		},
+		{
+			name:           "multiple entries",
+			input:          map[string]int{"a": 1, "b": 2, "c": 3},
+			expectedKeys:   []string{"a", "b", "c"},
+			expectedValues: []int{1, 2, 3},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			keys := MapKeys(tc.input)
+			sort.Strings(keys)
+			if !reflect.DeepEqual(keys, tc.expectedKeys) {
+				t.Errorf("MapKeys(%v) = %v; expected %v", tc.input, keys, tc.expectedKeys)
+			}
+
+			values := MapValues(tc.input)
+			sort.Ints(values)
+			if !reflect.DeepEqual(values, tc.expectedValues) {
+				t.Errorf("MapValues(%v) = %v; expected %v", tc.input, values, tc.expectedValues)
+			}
+		})
+	}
+}
+
+func TestTryReduce(t *testing.T) {
+	errNegative := errors.New("negative value")
+	sumPositive := func(acc, v int) (int, error) {
+		if v < 0 {
+			return acc, errNegative
+		}
+		return acc + v, nil
+	}
+
+	testCases := []struct {
+		name        string
+		input       []int
+		init        int
+		expected    int
+		expectedErr error
+	}{
+		{
+			name:     "empty slice",
+			input:    []int{},
+			init:     3,
+			expected: 3,
+		},
+		{
+			name:     "success",
+			input:    []int{1, 2, 3},
+			expected: 6,
+		},
+		{
+			name:        "stops at first error",
+			input:       []int{1, 2, -1, 4},
+			expected:    3,
+			expectedErr: errNegative,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := TryReduce(tc.input, tc.init, sumPositive)
+			if err != tc.expectedErr {
+				t.Errorf("TryReduce(%v) error = %v; expected %v", tc.input, err, tc.expectedErr)
+			}
+			if result != tc.expected {
+				t.Errorf("TryReduce(%v) = %v; expected %v", tc.input, result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestUniqueByLast(t *testing.T) {
+	type record struct {
+		id  int
+		ver int
+	}
+	byID := func(r record) int { return r.id }
+
+	testCases := []struct {
+		name     string
+		input    []record
+		expected []record
+	}{
+		{
+			name:     "empty slice",
+			input:    []record{},
+			expected: []record{},
+		},
+		{
+			name:     "no duplicates",
+			input:    []record{{1, 1}, {2, 1}},
+			expected: []record{{1, 1}, {2, 1}},
+		},
+		{
+			name:     "later records supersede earlier ones",
+			input:    []record{{1, 1}, {2, 1}, {1, 2}, {3, 1}, {2, 2}},
+			expected: []record{{1, 2}, {3, 1}, {2, 2}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := UniqueByLast(tc.input, byID)
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("UniqueByLast(%v) = %v; expected %v", tc.input, result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestCoalesce(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    []string
+		expected string
+	}{
+		{
+			name:     "no values",
+			input:    nil,
+			expected: "",
+		},
+		{
+			name:     "all zero",
+			input:    []string{"", ""},
+			expected: "",
+		},
+		{
+			name:     "first non-zero wins",
+			input:    []string{"", "env", "file"},
+			expected: "env",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := Coalesce(tc.input...)
+			if result != tc.expected {
+				t.Errorf("Coalesce(%q) = %q; expected %q", tc.input, result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestFilterIndexed(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    []string
+		pred     func(int, string) bool
+		expected []string
+	}{
+		{
+			name:     "empty slice",
+			input:    []string{},
+			pred:     func(i int, _ string) bool { return true },
+			expected: []string{},
+		},
+		{
+			name:     "every third element",
+			input:    []string{"a", "b", "c", "d", "e", "f", "g"},
+			pred:     func(i int, _ string) bool { return i%3 == 0 },
+			expected: []string{"a", "d", "g"},
+		},
+		{
+			name:     "index and element",
+			input:    []string{"a", "b", "c", "d"},
+			pred:     func(i int, e string) bool { return i%2 == 1 && e != "d" },
+			expected: []string{"b"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := FilterIndexed(tc.input, tc.pred)
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("FilterIndexed(%v) = %v; expected %v", tc.input, result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestSortedStableFunc(t *testing.T) {
+	type record struct {
+		group int
+		name  string
+	}
+	byGroup := func(a, b record) bool { return a.group < b.group }
+
+	testCases := []struct {
+		name     string
+		input    []record
+		expected []record
+	}{
+		{
+			name:     "empty slice",
+			input:    []record{},
+			expected: []record{},
+		},
+		{
+			name:     "ties keep input order",
+			input:    []record{{2, "a"}, {1, "b"}, {2, "c"}, {1, "d"}, {0, "e"}},
+			expected: []record{{0, "e"}, {1, "b"}, {1, "d"}, {2, "a"}, {2, "c"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := make([]record, len(tc.input))
+			copy(input, tc.input)
+
+			result := SortedStableFunc(tc.input, byGroup)
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("SortedStableFunc(%v) = %v; expected %v", tc.input, result, tc.expected)
+			}
+			if !reflect.DeepEqual(tc.input, input) {
+				t.Errorf("SortedStableFunc modified its input: %v", tc.input)
+			}
+		})
+	}
+}
+
+func TestToChannelAndFromChannel(t *testing.T) {
+	testCases := []struct {
+		name   string
+		input  []int
+		buffer int
+	}{
+		{
+			name:   "empty slice",
+			input:  []int{},
+			buffer: 0,
+		},
+		{
+			name:   "unbuffered",
+			input:  []int{1, 2, 3},
+			buffer: 0,
+		},
+		{
+			name:   "buffered",
+			input:  []int{1, 2, 3, 4, 5},
+			buffer: 2,
+		},
+		{
+			name:   "negative buffer",
+			input:  []int{1},
+			buffer: -1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := FromChannel(ToChannel(context.Background(), tc.input, tc.buffer))
+			if !reflect.DeepEqual(result, tc.input) {
+				t.Errorf("FromChannel(ToChannel(%v)) = %v; expected %v", tc.input, result, tc.input)
+			}
+		})
+	}
+
+	t.Run("cancel closes the channel", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		ch := ToChannel(ctx, []int{1, 2, 3}, 0)
+
+		if v := <-ch; v != 1 {
+			t.Fatalf("ToChannel() first value = %d; expected 1", v)
+		}
+		cancel()
+
+		// the goroutine may still win one pending send before it sees the cancellation
+		received := 0
+		for range ch {
+			received++
+		}
+		if received > 1 {
+			t.Errorf("ToChannel() sent %d values after cancel; expected at most 1", received)
+		}
+	})
+}
+
+func TestMinMax(t *testing.T) {
+	testCases := []struct {
+		name        string
+		input       []int
+		expectedMin int
+		expectedMax int
+		expectedOk  bool
+	}{
+		{
+			name:       "empty slice",
+			input:      []int{},
+			expectedOk: false,
+		},
+		{
+			name:        "single element",
+			input:       []int{7},
+			expectedMin: 7,
+			expectedMax: 7,
+			expectedOk:  true,
+		},
+		{
+			name:        "even length",
+			input:       []int{3, 1, 4, 1, 5, 9},
+			expectedMin: 1,
+			expectedMax: 9,
+			expectedOk:  true,
+		},
+		{
+			name:        "odd length",
+			input:       []int{-2, 8, 0, -7, 3},
+			expectedMin: -7,
+			expectedMax: 8,
+			expectedOk:  true,
+		},
+		{
+			name:        "descending pair",
+			input:       []int{5, 2},
+			expectedMin: 2,
+			expectedMax: 5,
+			expectedOk:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			min, max, ok := MinMax(tc.input)
+			if min != tc.expectedMin || max != tc.expectedMax || ok != tc.expectedOk {
+				t.Errorf("MinMax(%v) = (%v, %v, %v); expected (%v, %v, %v)",
+					tc.input, min, max, ok, tc.expectedMin, tc.expectedMax, tc.expectedOk)
+			}
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	evens := func(s []int) []int { return Filter(s, func(v int) bool { return v%2 == 0 }) }
+	firstTwo := func(s []int) []int { return Limit(s, 2) }
+	double := func(s []int) []int { return MustMap(s, func(v int) int { return v * 2 }) }
+
+	testCases := []struct {
+		name       string
+		input      []int
+		transforms []func([]int) []int
+		expected   []int
+	}{
+		{
+			name:     "no transforms",
+			input:    []int{1, 2, 3},
+			expected: []int{1, 2, 3},
+		},
+		{
+			name:       "left to right",
+			input:      []int{1, 2, 3, 4, 5, 6},
+			transforms: []func([]int) []int{evens, firstTwo, double},
+			expected:   []int{4, 8},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := Apply(tc.input, tc.transforms...)
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("Apply(%v) = %v; expected %v", tc.input, result, tc.expected)
+			}
+		})
+	}
+
+	t.Run("result does not alias input", func(t *testing.T) {
+		input := []int{1, 2, 3}
+		result := Apply(input)
+		result[0] = 100
+		if input[0] != 1 {
+			t.Errorf("Apply() without transforms returned the input slice itself")
+		}
+	})
+}
+
+func TestDedupFunc(t *testing.T) {
+	sameTens := func(a, b int) bool { return a/10 == b/10 }
+
+	testCases := []struct {
+		name     string
+		input    []int
+		expected []int
+	}{
+		{
+			name:     "empty slice",
+			input:    []int{},
+			expected: []int{},
+		},
+		{
+			name:     "single element",
+			input:    []int{5},
+			expected: []int{5},
+		},
+		{
+			name:     "adjacent runs collapse",
+			input:    []int{1, 5, 12, 15, 18, 21, 3},
+			expected: []int{1, 12, 21, 3},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := DedupFunc(tc.input, sameTens)
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("DedupFunc(%v) = %v; expected %v", tc.input, result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestProduct(t *testing.T) {
+	t.Run("int", func(t *testing.T) {
+		testCases := []struct {
+			name     string
+			input    []int
+			expected int
+		}{
+			{name: "empty slice", input: []int{}, expected: 1},
+			{name: "single element", input: []int{7}, expected: 7},
+			{name: "multiple elements", input: []int{2, 3, -4}, expected: -24},
+			{name: "with zero", input: []int{2, 0, 5}, expected: 0},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				if result := Product(tc.input); result != tc.expected {
+					t.Errorf("Product(%v) = %v; expected %v", tc.input, result, tc.expected)
+				}
+			})
+		}
+	})
+
+	t.Run("float", func(t *testing.T) {
+		if result := Product([]float64{1.5, 2, 0.5}); result != 1.5 {
+			t.Errorf("Product() = %v; expected 1.5", result)
+		}
+	})
+
+	t.Run("integer overflow wraps", func(t *testing.T) {
+		if result := Product([]uint8{16, 16, 3}); result != 0 {
+			t.Errorf("Product() = %v; expected 0", result)
+		}
+	})
+}
+
+func TestAtLeastAndAtMost(t *testing.T) {
+	approved := func(v string) bool { return v == "approve" }
+
+	testCases := []struct {
+		name            string
+		input           []string
+		n               int
+		expectedAtLeast bool
+		expectedAtMost  bool
+	}{
+		{
+			name:            "empty slice",
+			input:           []string{},
+			n:               1,
+			expectedAtLeast: false,
+			expectedAtMost:  true,
+		},
+		{
+			name:            "zero",
+			input:           []string{"approve"},
+			n:               0,
+			expectedAtLeast: true,
+			expectedAtMost:  false,
+		},
+		{
+			name:            "exactly n",
+			input:           []string{"approve", "reject", "approve"},
+			n:               2,
+			expectedAtLeast: true,
+			expectedAtMost:  true,
+		},
+		{
+			name:            "fewer than n",
+			input:           []string{"approve", "reject"},
+			n:               2,
+			expectedAtLeast: false,
+			expectedAtMost:  true,
+		},
+		{
+			name:            "more than n",
+			input:           []string{"approve", "approve", "approve"},
+			n:               2,
+			expectedAtLeast: true,
+			expectedAtMost:  false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if result := AtLeast(tc.input, tc.n, approved); result != tc.expectedAtLeast {
+				t.Errorf("AtLeast(%v, %d) = %v; expected %v", tc.input, tc.n, result, tc.expectedAtLeast)
+			}
+			if result := AtMost(tc.input, tc.n, approved); result != tc.expectedAtMost {
+				t.Errorf("AtMost(%v, %d) = %v; expected %v", tc.input, tc.n, result, tc.expectedAtMost)
+			}
+		})
+	}
+
+	t.Run("short circuit", func(t *testing.T) {
+		calls := 0
+		counting := func(v int) bool {
+			calls++
+			return true
+		}
+
+		AtLeast([]int{1, 2, 3, 4, 5}, 2, counting)
+		if calls != 2 {
+			t.Errorf("AtLeast() evaluated %d elements; expected 2", calls)
+		}
+
+		calls = 0
+		AtMost([]int{1, 2, 3, 4, 5}, 2, counting)
+		if calls != 3 {
+			t.Errorf("AtMost() evaluated %d elements; expected 3", calls)
+		}
+	})
+}
+
+func TestChunkEvenly(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    []int
+		parts    int
+		expected [][]int
+	}{
+		{
+			name:     "no parts",
+			input:    []int{1, 2, 3},
+			parts:    0,
+			expected: [][]int{},
+		},
+		{
+			name:     "divides evenly",
+			input:    []int{1, 2, 3, 4, 5, 6},
+			parts:    3,
+			expected: [][]int{{1, 2}, {3, 4}, {5, 6}},
+		},
+		{
+			name:     "remainder goes to earlier chunks",
+			input:    []int{1, 2, 3, 4, 5, 6, 7, 8},
+			parts:    3,
+			expected: [][]int{{1, 2, 3}, {4, 5, 6}, {7, 8}},
+		},
+		{
+			name:     "more parts than elements",
+			input:    []int{1, 2},
+			parts:    4,
+			expected: [][]int{{1}, {2}, {}, {}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := ChunkEvenly(tc.input, tc.parts)
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("ChunkEvenly(%v, %d) = %v; expected %v", tc.input, tc.parts, result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestReduceRight(t *testing.T) {
+	nest := func(e string, acc string) string { return "(" + e + " " + acc + ")" }
+
+	testCases := []struct {
+		name     string
+		input    []string
+		init     string
+		expected string
+	}{
+		{
+			name:     "empty slice",
+			input:    []string{},
+			init:     "nil",
+			expected: "nil",
+		},
+		{
+			name:     "right nested",
+			input:    []string{"a", "b", "c"},
+			init:     "nil",
+			expected: "(a (b (c nil)))",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := ReduceRight(tc.input, tc.init, nest)
+			if result != tc.expected {
+				t.Errorf("ReduceRight(%v) = %q; expected %q", tc.input, result, tc.expected)
+			}
+		})
+	}
+
+	t.Run("accumulator type differs", func(t *testing.T) {
+		result := ReduceRight([]int{1, 2, 3}, "", func(e int, acc string) string {
+			return acc + fmt.Sprint(e)
+		})
+		if result != "321" {
+			t.Errorf("ReduceRight() = %q; expected %q", result, "321")
+		}
+	})
+}
+
+func TestToMapMerge(t *testing.T) {
+	type lineItem struct {
+		product string
+		qty     int
+	}
+	kv := func(li lineItem) (string, int) { return li.product, li.qty }
+	sum := func(existing, new int) int { return existing + new }
+
+	testCases := []struct {
+		name     string
+		input    []lineItem
+		expected map[string]int
+	}{
+		{
+			name:     "empty slice",
+			input:    []lineItem{},
+			expected: map[string]int{},
+		},
+		{
+			name:     "no collisions",
+			input:    []lineItem{{"a", 1}, {"b", 2}},
+			expected: map[string]int{"a": 1, "b": 2},
+		},
+		{
+			name:     "collisions are merged",
+			input:    []lineItem{{"a", 1}, {"b", 2}, {"a", 3}, {"a", 4}},
+			expected: map[string]int{"a": 8, "b": 2},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := ToMapMerge(tc.input, kv, sum)
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("ToMapMerge(%v) = %v; expected %v", tc.input, result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestSpan(t *testing.T) {
+	notBlank := func(line string) bool { return line != "" }
+
+	testCases := []struct {
+		name           string
+		input          []string
+		expectedPrefix []string
+		expectedRest   []string
+	}{
+		{
+			name:           "nil slice",
+			input:          nil,
+			expectedPrefix: []string{},
+			expectedRest:   []string{},
+		},
+		{
+			name:           "headers then body",
+			input:          []string{"Host: a", "Accept: b", "", "body", ""},
+			expectedPrefix: []string{"Host: a", "Accept: b"},
+			expectedRest:   []string{"", "body", ""},
+		},
+		{
+			name:           "all match",
+			input:          []string{"a", "b"},
+			expectedPrefix: []string{"a", "b"},
+			expectedRest:   []string{},
+		},
+		{
+			name:           "first fails",
+			input:          []string{"", "a"},
+			expectedPrefix: []string{},
+			expectedRest:   []string{"", "a"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			prefix, rest := Span(tc.input, notBlank)
+			if !reflect.DeepEqual(prefix, tc.expectedPrefix) {
+				t.Errorf("Span(%q) prefix = %q; expected %q", tc.input, prefix, tc.expectedPrefix)
+			}
+			if !reflect.DeepEqual(rest, tc.expectedRest) {
+				t.Errorf("Span(%q) rest = %q; expected %q", tc.input, rest, tc.expectedRest)
+			}
+		})
+	}
+}
+
+func TestEnumerate(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    []string
+		expected []Pair[int, string]
+	}{
+		{
+			name:     "empty slice",
+			input:    []string{},
+			expected: []Pair[int, string]{},
+		},
+		{
+			name:     "multiple elements",
+			input:    []string{"a", "b", "c"},
+			expected: []Pair[int, string]{{0, "a"}, {1, "b"}, {2, "c"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := Enumerate(tc.input)
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("Enumerate(%v) = %v; expected %v", tc.input, result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	testCases := []struct {
+		name       string
+		input      []float64
+		bucketSize float64
+		expected   map[int]int
+	}{
+		{
+			name:       "empty slice",
+			input:      []float64{},
+			bucketSize: 10,
+			expected:   map[int]int{},
+		},
+		{
+			name:       "latencies",
+			input:      []float64{1, 9.9, 10, 15, 25, 99},
+			bucketSize: 10,
+			expected:   map[int]int{0: 2, 1: 2, 2: 1, 9: 1},
+		},
+		{
+			name:       "negative values floor down",
+			input:      []float64{-0.5, -10, -10.5},
+			bucketSize: 10,
+			expected:   map[int]int{-1: 2, -2: 1},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := Histogram(tc.input, tc.bucketSize)
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("Histogram(%v, %v) = %v; expected %v", tc.input, tc.bucketSize, result, tc.expected)
+			}
+		})
+	}
+
+	t.Run("integers", func(t *testing.T) {
+		result := Histogram([]int{1, 2, 3, 4}, 2)
+		expected := map[int]int{0: 1, 1: 2, 2: 1}
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Histogram() = %v; expected %v", result, expected)
+		}
+	})
+
+	t.Run("invalid bucket size", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("Histogram() with bucket size 0 expected panic")
+			}
+		}()
+		Histogram([]int{1}, 0)
+	})
+
+	for _, v := range []float64{math.NaN(), math.Inf(1), math.Inf(-1), 1e300, -1e300} {
+		t.Run(fmt.Sprintf("out of range %v", v), func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Histogram() with value %v expected panic", v)
+				}
+			}()
+			Histogram([]float64{1, v}, 10)
+		})
+	}
+}
+
+func TestGroupByComposite(t *testing.T) {
+	type account struct {
+		region string
+		tier   int
+		name   string
+	}
+	byRegionTier := func(a account) []any { return []any{a.region, a.tier} }
+
+	accounts := []account{
+		{"eu", 1, "a"},
+		{"us", 1, "b"},
+		{"eu", 1, "c"},
+		{"eu", 2, "d"},
+	}
+
+	result := GroupByComposite(accounts, byRegionTier)
+	if len(result) != 3 {
+		t.Fatalf("GroupByComposite() returned %d groups; expected 3", len(result))
+	}
+
+	expected := map[string][]account{
+		compositeKey([]any{"eu", 1}): {{"eu", 1, "a"}, {"eu", 1, "c"}},
+		compositeKey([]any{"us", 1}): {{"us", 1, "b"}},
+		compositeKey([]any{"eu", 2}): {{"eu", 2, "d"}},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("GroupByComposite() = %v; expected %v", result, expected)
+	}
+
+	t.Run("empty slice", func(t *testing.T) {
+		if result := GroupByComposite([]account{}, byRegionTier); len(result) != 0 {
+			t.Errorf("GroupByComposite() = %v; expected empty map", result)
+		}
+	})
+
+	t.Run("distinct tuples never collide", func(t *testing.T) {
+		tuples := [][]any{
+			{"a:b", "c"},
+			{"a", "b:c"},
+			{"ab", "c"},
+			{"a", "bc"},
+			{1, 2},
+			{"1", "2"},
+			{int64(1), 2},
+			{12},
+			{},
+			{""},
+			{nil},
+		}
+		seen := map[string]int{}
+		for i, tuple := range tuples {
+			k := compositeKey(tuple)
+			if j, ok := seen[k]; ok {
+				t.Errorf("compositeKey(%v) collides with compositeKey(%v): %q", tuple, tuples[j], k)
+			}
+			seen[k] = i
+		}
+	})
+
+	t.Run("distinct pointers group separately", func(t *testing.T) {
+		type point struct{ x int }
+		p1, p2 := &point{1}, &point{1}
+		items := []*point{p1, p2, p1}
+
+		result := GroupByComposite(items, func(p *point) []any { return []any{p} })
+		if len(result) != 2 {
+			t.Fatalf("GroupByComposite() returned %d groups; expected 2", len(result))
+		}
+		if got := result[compositeKey([]any{p1})]; len(got) != 2 {
+			t.Errorf("GroupByComposite() grouped %d items under p1; expected 2", len(got))
+		}
+	})
+}
+
+func TestForEachBatchParallel(t *testing.T) {
+	input := make([]int, 103)
+	for i := range input {
+		input[i] = i
+	}
+
+	testCases := []struct {
+		name        string
+		batchSize   int
+		workers     int
+		wantBatches int
+	}{
+		{name: "batches across workers", batchSize: 10, workers: 4, wantBatches: 11},
+		{name: "default workers", batchSize: 50, workers: 0, wantBatches: 3},
+		{name: "single batch by default", batchSize: 0, workers: 2, wantBatches: 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var mu sync.Mutex
+			batches := 0
+			seen := make([]int, 0, len(input))
+
+			err := ForEachBatchParallel(input, tc.batchSize, tc.workers, func(batch []int) error {
+				mu.Lock()
+				defer mu.Unlock()
+				batches++
+				seen = append(seen, batch...)
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("ForEachBatchParallel() returned error: %v", err)
+			}
+
+			sort.Ints(seen)
+			if !reflect.DeepEqual(seen, input) {
+				t.Errorf("ForEachBatchParallel() visited %v; expected %v", seen, input)
+			}
+			if batches != tc.wantBatches {
+				t.Errorf("ForEachBatchParallel() ran %d batches; expected %d", batches, tc.wantBatches)
+			}
+		})
+	}
+
+	t.Run("empty slice", func(t *testing.T) {
+		err := ForEachBatchParallel([]int{}, 10, 2, func(batch []int) error {
+			t.Error("f must not be called for an empty slice")
+			return nil
+		})
+		if err != nil {
+			t.Errorf("ForEachBatchParallel() returned error: %v", err)
+		}
+	})
+
+	t.Run("first error stops remaining batches", func(t *testing.T) {
+		wantErr := errors.New("insert failed")
+		var mu sync.Mutex
+		calls := 0
+
+		err := ForEachBatchParallel(input, 1, 1, func(batch []int) error {
+			mu.Lock()
+			defer mu.Unlock()
+			calls++
+			if batch[0] == 5 {
+				return wantErr
+			}
+			return nil
+		})
+		if err != wantErr {
+			t.Errorf("ForEachBatchParallel() error = %v; expected %v", err, wantErr)
+		}
+		if calls != 6 {
+			t.Errorf("ForEachBatchParallel() called f %d times; expected 6", calls)
+		}
+	})
+}
+
+func TestUniqueWithSeen(t *testing.T) {
+	testCases := []struct {
+		name         string
+		input        []string
+		expected     []string
+		expectedSeen map[string]struct{}
+	}{
+		{
+			name:         "empty slice",
+			input:        []string{},
+			expected:     []string{},
+			expectedSeen: map[string]struct{}{},
+		},
+		{
+			name:     "with duplicates",
+			input:    []string{"b", "a", "b", "c", "a"},
+			expected: []string{"b", "a", "c"},
+			expectedSeen: map[string]struct{}{
+				"a": {},
+				"b": {},
+				"c": {},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, seen := UniqueWithSeen(tc.input)
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("UniqueWithSeen(%v) = %v; expected %v", tc.input, result, tc.expected)
+			}
+			if !reflect.DeepEqual(seen, tc.expectedSeen) {
+				t.Errorf("UniqueWithSeen(%v) seen = %v; expected %v", tc.input, seen, tc.expectedSeen)
+			}
+		})
+	}
+}
+
+func TestSortedByKey(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    []string
+		expected []string
+	}{
+		{
+			name:     "empty slice",
+			input:    []string{},
+			expected: []string{},
+		},
+		{
+			name:     "by parsed number",
+			input:    []string{"10", "9", "100", "1"},
+			expected: []string{"1", "9", "10", "100"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := make([]string, len(tc.input))
+			copy(input, tc.input)
+
+			calls := 0
+			result := SortedByKey(tc.input, func(v string) int {
+				calls++
+				n, _ := strconv.Atoi(v)
+				return n
+			})
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("SortedByKey(%v) = %v; expected %v", tc.input, result, tc.expected)
+			}
+			if calls != len(tc.input) {
+				t.Errorf("SortedByKey() called key %d times; expected %d", calls, len(tc.input))
+			}
+			if !reflect.DeepEqual(tc.input, input) {
+				t.Errorf("SortedByKey modified its input: %v", tc.input)
+			}
+		})
+	}
+}
+
+func TestFlattenDeep(t *testing.T) {
+	nested := []any{1, []any{2, []any{3, []any{4}}}, []int{5, 6}, "x", nil}
+
+	testCases := []struct {
+		name     string
+		input    any
+		depth    int
+		expected []any
+	}{
+		{
+			name:     "empty slice",
+			input:    []any{},
+			depth:    -1,
+			expected: []any{},
+		},
+		{
+			name:     "non-slice value",
+			input:    "leaf",
+			depth:    -1,
+			expected: []any{"leaf"},
+		},
+		{
+			name:     "depth zero",
+			input:    nested,
+			depth:    0,
+			expected: []any{1, []any{2, []any{3, []any{4}}}, []int{5, 6}, "x", nil},
+		},
+		{
+			name:     "depth one",
+			input:    nested,
+			depth:    1,
+			expected: []any{1, 2, []any{3, []any{4}}, 5, 6, "x", nil},
+		},
+		{
+			name:     "fully",
+			input:    nested,
+			depth:    -1,
+			expected: []any{1, 2, 3, 4, 5, 6, "x", nil},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := FlattenDeep(tc.input, tc.depth)
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("FlattenDeep(%v, %d) = %v; expected %v", tc.input, tc.depth, result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestReduceWindows(t *testing.T) {
+	maxOf := func(w []int) int {
+		_, m, _ := MinMax(w)
+		return m
+	}
+
+	testCases := []struct {
+		name     string
+		input    []int
+		size     int
+		step     int
+		expected []int
+	}{
+		{
+			name:     "empty slice",
+			input:    []int{},
+			size:     2,
+			step:     1,
+			expected: []int{},
+		},
+		{
+			name:     "sliding max",
+			input:    []int{1, 3, 2, 5, 4, 1},
+			size:     3,
+			step:     1,
+			expected: []int{3, 5, 5, 5},
+		},
+		{
+			name:     "tumbling windows drop the partial tail",
+			input:    []int{1, 3, 2, 5, 4},
+			size:     2,
+			step:     2,
+			expected: []int{3, 5},
+		},
+		{
+			name:     "window larger than slice",
+			input:    []int{1, 2},
+			size:     3,
+			step:     1,
+			expected: []int{},
+		},
+		{
+			name:     "invalid size",
+			input:    []int{1, 2},
+			size:     0,
+			step:     1,
+			expected: []int{},
+		},
+		{
+			name:     "invalid step",
+			input:    []int{1, 2},
+			size:     1,
+			step:     0,
+			expected: []int{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := ReduceWindows(tc.input, tc.size, tc.step, maxOf)
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("ReduceWindows(%v, %d, %d) = %v; expected %v", tc.input, tc.size, tc.step, result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestDedupBy(t *testing.T) {
+	type event struct {
+		user string
+		seq  int
+	}
+	byUser := func(e event) string { return e.user }
+
+	testCases := []struct {
+		name     string
+		input    []event
+		expected []event
+	}{
+		{
+			name:     "empty slice",
+			input:    []event{},
+			expected: []event{},
+		},
+		{
+			name:     "keeps first of each run",
+			input:    []event{{"a", 1}, {"a", 2}, {"b", 3}, {"a", 4}, {"a", 5}},
+			expected: []event{{"a", 1}, {"b", 3}, {"a", 4}},
+		},
+		{
+			name:     "zero value key first",
+			input:    []event{{"", 1}, {"", 2}, {"a", 3}},
+			expected: []event{{"", 1}, {"a", 3}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := DedupBy(tc.input, byUser)
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("DedupBy(%v) = %v; expected %v", tc.input, result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestPad(t *testing.T) {
+	testCases := []struct {
+		name          string
+		input         []string
+		length        int
+		expectedRight []string
+		expectedLeft  []string
+	}{
+		{
+			name:          "empty slice",
+			input:         []string{},
+			length:        2,
+			expectedRight: []string{"-", "-"},
+			expectedLeft:  []string{"-", "-"},
+		},
+		{
+			name:          "shorter than length",
+			input:         []string{"a", "b"},
+			length:        4,
+			expectedRight: []string{"a", "b", "-", "-"},
+			expectedLeft:  []string{"-", "-", "a", "b"},
+		},
+		{
+			name:          "longer than length is not truncated",
+			input:         []string{"a", "b", "c"},
+			length:        2,
+			expectedRight: []string{"a", "b", "c"},
+			expectedLeft:  []string{"a", "b", "c"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			right := PadRight(tc.input, tc.length, "-")
+			if !reflect.DeepEqual(right, tc.expectedRight) {
+				t.Errorf("PadRight(%v, %d) = %v; expected %v", tc.input, tc.length, right, tc.expectedRight)
+			}
+
+			left := PadLeft(tc.input, tc.length, "-")
+			if !reflect.DeepEqual(left, tc.expectedLeft) {
+				t.Errorf("PadLeft(%v, %d) = %v; expected %v", tc.input, tc.length, left, tc.expectedLeft)
+			}
+		})
+	}
+
+	t.Run("returns copies", func(t *testing.T) {
+		input := []string{"a", "b"}
+		PadRight(input, 1, "-")[0] = "x"
+		PadLeft(input, 1, "-")[0] = "x"
+		if input[0] != "a" {
+			t.Errorf("Pad functions returned the input slice itself")
+		}
+	})
+}
+
+func TestMapCollectErrors(t *testing.T) {
+	errInvalid := errors.New("invalid number")
+	parse := func(v string) (int, error) {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return 0, errInvalid
+		}
+		return n, nil
+	}
+
+	testCases := []struct {
+		name           string
+		input          []string
+		expected       []int
+		expectedErrors []string
+	}{
+		{
+			name:     "empty slice",
+			input:    []string{},
+			expected: []int{},
+		},
+		{
+			name:     "all valid",
+			input:    []string{"1", "2"},
+			expected: []int{1, 2},
+		},
+		{
+			name:           "collects every error",
+			input:          []string{"1", "x", "3", "y"},
+			expected:       []int{1, 3},
+			expectedErrors: []string{"index 1: invalid number", "index 3: invalid number"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, errs := MapCollectErrors(tc.input, parse)
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("MapCollectErrors(%v) = %v; expected %v", tc.input, result, tc.expected)
+			}
+			if len(errs) != len(tc.expectedErrors) {
+				t.Fatalf("MapCollectErrors(%v) returned %d errors; expected %d", tc.input, len(errs), len(tc.expectedErrors))
+			}
+			for i, err := range errs {
+				if err.Error() != tc.expectedErrors[i] || !errors.Is(err, errInvalid) {
+					t.Errorf("MapCollectErrors(%v) error %d = %v; expected %v", tc.input, i, err, tc.expectedErrors[i])
+				}
+			}
+		})
+	}
+}
+
+func TestTranspose(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    [][]int
+		expected [][]int
+	}{
+		{
+			name:     "empty input",
+			input:    [][]int{},
+			expected: [][]int{},
+		},
+		{
+			name:     "rectangular",
+			input:    [][]int{{1, 2, 3}, {4, 5, 6}},
+			expected: [][]int{{1, 4}, {2, 5}, {3, 6}},
+		},
+		{
+			name:     "ragged rows are zero padded",
+			input:    [][]int{{1, 2, 3}, {4}, {5, 6}},
+			expected: [][]int{{1, 4, 5}, {2, 0, 6}, {3, 0, 0}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := Transpose(tc.input)
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("Transpose(%v) = %v; expected %v", tc.input, result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestMinAndMax(t *testing.T) {
+	testCases := []struct {
+		name        string
+		input       []int
+		expectedMin int
+		expectedMax int
+		expectedOk  bool
+	}{
+		{
+			name:       "empty slice",
+			input:      []int{},
+			expectedOk: false,
+		},
+		{
+			name:        "single element",
+			input:       []int{4},
+			expectedMin: 4,
+			expectedMax: 4,
+			expectedOk:  true,
+		},
+		{
+			name:        "negatives",
+			input:       []int{-3, -10, -1},
+			expectedMin: -10,
+			expectedMax: -1,
+			expectedOk:  true,
+		},
+		{
+			name:        "ties",
+			input:       []int{2, 7, 2, 7},
+			expectedMin: 2,
+			expectedMax: 7,
+			expectedOk:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			min, ok := Min(tc.input)
+			if min != tc.expectedMin || ok != tc.expectedOk {
+				t.Errorf("Min(%v) = (%v, %v); expected (%v, %v)", tc.input, min, ok, tc.expectedMin, tc.expectedOk)
+			}
+
+			max, ok := Max(tc.input)
+			if max != tc.expectedMax || ok != tc.expectedOk {
+				t.Errorf("Max(%v) = (%v, %v); expected (%v, %v)", tc.input, max, ok, tc.expectedMax, tc.expectedOk)
+			}
+		})
+	}
+
+	t.Run("floats and strings", func(t *testing.T) {
+		if v, _ := Min([]float64{1.5, -0.5, 2}); v != -0.5 {
+			t.Errorf("Min() = %v; expected -0.5", v)
+		}
+		if v, _ := Max([]string{"pear", "apple", "zucchini"}); v != "zucchini" {
+			t.Errorf("Max() = %v; expected zucchini", v)
+		}
+	})
+}
+
+func TestFindIndexed(t *testing.T) {
+	isNegative := func(v int) bool { return v < 0 }
+
+	testCases := []struct {
+		name          string
+		input         []int
+		expectedIndex int
+		expectedElem  int
+		expectedFound bool
+	}{
+		{
+			name:          "empty slice",
+			input:         []int{},
+			expectedIndex: -1,
+		},
+		{
+			name:          "no match",
+			input:         []int{1, 2, 3},
+			expectedIndex: -1,
+		},
+		{
+			name:          "first match",
+			input:         []int{1, -2, 3, -4},
+			expectedIndex: 1,
+			expectedElem:  -2,
+			expectedFound: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			i, e, found := FindIndexed(tc.input, isNegative)
+			if i != tc.expectedIndex || e != tc.expectedElem || found != tc.expectedFound {
+				t.Errorf("FindIndexed(%v) = (%v, %v, %v); expected (%v, %v, %v)",
+					tc.input, i, e, found, tc.expectedIndex, tc.expectedElem, tc.expectedFound)
+			}
+		})
+	}
+}
+
+func TestMinFuncAndMaxFunc(t *testing.T) {
+	type order struct {
+		id    string
+		price int
+	}
+	byPrice := func(a, b order) bool { return a.price < b.price }
+
+	testCases := []struct {
+		name        string
+		input       []order
+		expectedMin order
+		expectedMax order
+		expectedOk  bool
+	}{
+		{
+			name:       "empty slice",
+			input:      []order{},
+			expectedOk: false,
+		},
+		{
+			name:        "single element",
+			input:       []order{{"a", 5}},
+			expectedMin: order{"a", 5},
+			expectedMax: order{"a", 5},
+			expectedOk:  true,
+		},
+		{
+			name:        "first encountered wins ties",
+			input:       []order{{"a", 5}, {"b", 1}, {"c", 9}, {"d", 1}, {"e", 9}},
+			expectedMin: order{"b", 1},
+			expectedMax: order{"c", 9},
+			expectedOk:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			min, ok := MinFunc(tc.input, byPrice)
+			if min != tc.expectedMin || ok != tc.expectedOk {
+				t.Errorf("MinFunc(%v) = (%v, %v); expected (%v, %v)", tc.input, min, ok, tc.expectedMin, tc.expectedOk)
+			}
+
+			max, ok := MaxFunc(tc.input, byPrice)
+			if max != tc.expectedMax || ok != tc.expectedOk {
+				t.Errorf("MaxFunc(%v) = (%v, %v); expected (%v, %v)", tc.input, max, ok, tc.expectedMax, tc.expectedOk)
+			}
+		})
+	}
+}
+
+func TestSort(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    []int
+		expected []int
+	}{
+		{
+			name:     "empty slice",
+			input:    []int{},
+			expected: []int{},
+		},
+		{
+			name:     "unsorted",
+			input:    []int{3, -1, 2, 2, 0},
+			expected: []int{-1, 0, 2, 2, 3},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := make([]int, len(tc.input))
+			copy(input, tc.input)
+
+			result := Sort(tc.input)
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("Sort(%v) = %v; expected %v", tc.input, result, tc.expected)
+			}
+			if !reflect.DeepEqual(tc.input, input) {
+				t.Errorf("Sort modified its input: %v", tc.input)
+			}
+
+			desc := SortFunc(tc.input, func(a, b int) bool { return a > b })
+			for i := range desc {
+				if desc[i] != tc.expected[len(tc.expected)-1-i] {
+					t.Errorf("SortFunc(%v) = %v; expected reverse of %v", tc.input, desc, tc.expected)
+					break
+				}
+			}
+			if !reflect.DeepEqual(tc.input, input) {
+				t.Errorf("SortFunc modified its input: %v", tc.input)
+			}
+		})
+	}
+}
+
+func TestCycle(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    []string
+		length   int
+		expected []string
+	}{
+		{
+			name:     "empty slice",
+			input:    []string{},
+			length:   3,
+			expected: []string{},
+		},
+		{
+			name:     "zero length",
+			input:    []string{"a"},
+			length:   0,
+			expected: []string{},
+		},
+		{
+			name:     "shorter than input",
+			input:    []string{"a", "b", "c"},
+			length:   2,
+			expected: []string{"a", "b"},
+		},
+		{
+			name:     "repeats",
+			input:    []string{"a", "b"},
+			length:   5,
+			expected: []string{"a", "b", "a", "b", "a"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := Cycle(tc.input, tc.length)
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("Cycle(%v, %d) = %v; expected %v", tc.input, tc.length, result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestReverse(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    []int
+		expected []int
+	}{
+		{
+			name:     "empty slice",
+			input:    []int{},
+			expected: nil,
+		},
+		{
+			name:     "single element",
+			input:    []int{1},
+			expected: []int{1},
+		},
+		{
+			name:     "multiple elements",
+			input:    []int{1, 2, 3, 4},
+			expected: []int{4, 3, 2, 1},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := make([]int, len(tc.input))
+			copy(input, tc.input)
+
+			result := Reverse(tc.input)
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("Reverse(%v) = %v; expected %v", tc.input, result, tc.expected)
+			}
+			if !reflect.DeepEqual(tc.input, input) {
+				t.Errorf("Reverse modified its input: %v", tc.input)
+			}
+		})
+	}
+}
+
+func TestChunk(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    []int
+		size     int
+		expected [][]int
+	}{
+		{
+			name:     "empty slice",
+			input:    []int{},
+			size:     2,
+			expected: [][]int{},
+		},
+		{
+			name:     "zero size",
+			input:    []int{1, 2, 3},
+			size:     0,
+			expected: [][]int{},
+		},
+		{
+			name:     "negative size",
+			input:    []int{1, 2, 3},
+			size:     -1,
+			expected: [][]int{},
+		},
+		{
+			name:     "exact chunks",
+			input:    []int{1, 2, 3, 4},
+			size:     2,
+			expected: [][]int{{1, 2}, {3, 4}},
+		},
+		{
+			name:     "shorter final chunk",
+			input:    []int{1, 2, 3, 4, 5},
+			size:     2,
+			expected: [][]int{{1, 2}, {3, 4}, {5}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := Chunk(tc.input, tc.size)
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("Chunk(%v, %d) = %v; expected %v", tc.input, tc.size, result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestMapGroups(t *testing.T) {
+	total := func(_ string, items []int) int {
+		sum := 0
+		for _, v := range items {
+			sum += v
+		}
+		return sum
+	}
+
+	testCases := []struct {
+		name     string
+		input    map[string][]int
+		expected map[string]int
+	}{
+		{
+			name:     "empty map",
+			input:    map[string][]int{},
+			expected: map[string]int{},
+		},
+		{
+			name:     "per group totals",
+			input:    map[string][]int{"odd": {1, 3, 5}, "even": {2, 4}, "none": {}},
+			expected: map[string]int{"odd": 9, "even": 6, "none": 0},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := MapGroups(tc.input, total)
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("MapGroups(%v) = %v; expected %v", tc.input, result, tc.expected)
+			}
+		})
+	}
+
+	t.Run("after GroupBy", func(t *testing.T) {
+		groups := GroupBy([]string{"apple", "avocado", "banana"}, func(s string) byte { return s[0] })
+		counts := MapGroups(groups, func(_ byte, items []string) int { return len(items) })
+		expected := map[byte]int{'a': 2, 'b': 1}
+		if !reflect.DeepEqual(counts, expected) {
+			t.Errorf("MapGroups() = %v; expected %v", counts, expected)
+		}
+	})
+}
+
+func TestPartition(t *testing.T) {
+	isEven := func(v int) bool { return v%2 == 0 }
+
+	testCases := []struct {
+		name              string
+		input             []int
+		expectedMatched   []int
+		expectedUnmatched []int
+	}{
+		{
+			name:              "empty slice",
+			input:             []int{},
+			expectedMatched:   []int{},
+			expectedUnmatched: []int{},
+		},
+		{
+			name:              "mixed",
+			input:             []int{1, 2, 3, 4, 5, 6},
+			expectedMatched:   []int{2, 4, 6},
+			expectedUnmatched: []int{1, 3, 5},
+		},
+		{
+			name:              "all match",
+			input:             []int{2, 4},
+			expectedMatched:   []int{2, 4},
+			expectedUnmatched: []int{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			matched, unmatched := Partition(tc.input, isEven)
+			if !reflect.DeepEqual(matched, tc.expectedMatched) {
+				t.Errorf("Partition(%v) matched = %v; expected %v", tc.input, matched, tc.expectedMatched)
+			}
+			if !reflect.DeepEqual(unmatched, tc.expectedUnmatched) {
+				t.Errorf("Partition(%v) unmatched = %v; expected %v", tc.input, unmatched, tc.expectedUnmatched)
+			}
+
+			combined := append(append([]int{}, matched...), unmatched...)
+			if len(combined) != len(tc.input) {
+				t.Errorf("Partition(%v) produced %d elements; expected %d", tc.input, len(combined), len(tc.input))
+			}
+			sort.Ints(combined)
+			expected := append([]int{}, tc.input...)
+			sort.Ints(expected)
+			if !reflect.DeepEqual(combined, expected) {
+				t.Errorf("Partition(%v) elements = %v; expected each input element exactly once", tc.input, combined)
+			}
+		})
+	}
+}
+
+func TestSumAndAverage(t *testing.T) {
+	testCases := []struct {
+		name        string
+		input       []int64
+		expectedSum int64
+		expectedAvg float64
+		expectedOk  bool
+	}{
+		{
+			name:       "empty slice",
+			input:      []int64{},
+			expectedOk: false,
+		},
+		{
+			name:        "mixed negatives",
+			input:       []int64{-5, 10, -3, 2},
+			expectedSum: 4,
+			expectedAvg: 1,
+			expectedOk:  true,
+		},
+		{
+			name:        "overflow adjacent",
+			input:       []int64{math.MaxInt64 - 1, 1, -10, 10},
+			expectedSum: math.MaxInt64,
+			expectedAvg: float64(math.MaxInt64) / 4,
+			expectedOk:  true,
+		},
+		{
+			name:        "min and max cancel out",
+			input:       []int64{math.MinInt64 + 1, math.MaxInt64},
+			expectedSum: 0,
+			expectedAvg: 0,
+			expectedOk:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if sum := Sum(tc.input); sum != tc.expectedSum {
+				t.Errorf("Sum(%v) = %v; expected %v", tc.input, sum, tc.expectedSum)
+			}
+
+			avg, ok := Average(tc.input)
+			if ok != tc.expectedOk || math.Abs(avg-tc.expectedAvg) > 1e-6*math.Max(1, math.Abs(tc.expectedAvg)) {
+				t.Errorf("Average(%v) = (%v, %v); expected (%v, %v)", tc.input, avg, ok, tc.expectedAvg, tc.expectedOk)
+			}
+		})
+	}
+
+	t.Run("floats", func(t *testing.T) {
+		if sum := Sum([]float64{0.5, 1.25, -0.75}); sum != 1 {
+			t.Errorf("Sum() = %v; expected 1", sum)
+		}
+		if avg, _ := Average([]float64{1, 2}); avg != 1.5 {
+			t.Errorf("Average() = %v; expected 1.5", avg)
+		}
+	})
+}
+
+func TestSplitByBool(t *testing.T) {
+	isSpace := func(r rune) bool { return r == ' ' }
+
+	testCases := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{
+			name:     "empty input",
+			input:    "",
+			expected: []string{},
+		},
+		{
+			name:     "single run",
+			input:    "word",
+			expected: []string{"word"},
+		},
+		{
+			name:     "words and whitespace",
+			input:    "  hello  big world ",
+			expected: []string{"  ", "hello", "  ", "big", " ", "world", " "},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			runs := SplitByBool([]rune(tc.input), isSpace)
+			result := MustMap(runs, func(run []rune) string { return string(run) })
+			if result == nil {
+				result = []string{}
+			}
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("SplitByBool(%q) = %q; expected %q", tc.input, result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestSumByAndCountBy(t *testing.T) {
+	var getKeyFunc = func(i int) int {
+		return i % 2
+	}
+	var getValFunc = func(i int) float64 {
+		return float64(i)
+	}
+
+	tests := []struct {
+		name      string
+		s         []int
+		wantSum   map[int]float64
+		wantCount map[int]int
+	}{
+		{
+			name:      "Empty slice",
+			s:         []int{},
+			wantSum:   map[int]float64{},
+			wantCount: map[int]int{},
+		},
+		{
+			name:      "Slice with single element",
+			s:         []int{5},
+			wantSum:   map[int]float64{1: 5},
+			wantCount: map[int]int{1: 1},
+		},
+		{
+			name:      "Slice with multiple elements",
+			s:         []int{1, 2, 3, 4, 5},
+			wantSum:   map[int]float64{0: 6, 1: 9},
+			wantCount: map[int]int{0: 2, 1: 3},
+		},
+		{
+			name:      "Slice with duplicate elements",
+			s:         []int{1, 2, 2, 3, 3, 3, 4, 4, 4, 4},
+			wantSum:   map[int]float64{0: 20, 1: 10},
+			wantCount: map[int]int{0: 6, 1: 4},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := SumBy(test.s, getKeyFunc, getValFunc); !reflect.DeepEqual(got, test.wantSum) {
+				t.Errorf("SumBy() = %v, want %v", got, test.wantSum)
+			}
+			if got := CountBy(test.s, getKeyFunc); !reflect.DeepEqual(got, test.wantCount) {
+				t.Errorf("CountBy() = %v, want %v", got, test.wantCount)
+			}
+		})
+	}
+}